@@ -0,0 +1,39 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	SpecVersion = "1.0"
+	// ContentType is the media type of an event in the CloudEvents JSON
+	// format, which the adapters send in structured content mode.
+	ContentType = "application/cloudevents+json"
+)
+
+// Event is a CloudEvents 1.0 event in its JSON format. PartitionKey and
+// Actor are extension attributes: events with the same PartitionKey are
+// delivered in order, and Actor is the identity that caused the event.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	PartitionKey    string          `json:"partitionkey,omitempty"`
+	Actor           string          `json:"actor,omitempty"`
+}
+
+// Publisher sends events to a message broker. Publish returns once the
+// broker has accepted the event, so a caller that only forgets events after
+// a nil error delivers them at least once.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+	Close() error
+}
@@ -0,0 +1,111 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var event = Event{
+	SpecVersion:     SpecVersion,
+	ID:              "42",
+	Source:          "/api/v1/skills",
+	Type:            "skill.updated",
+	Subject:         "go",
+	Time:            time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+	DataContentType: "application/json",
+	DataSchema:      "urn:skillsapi:schema:skill.updated:v1",
+	Data:            json.RawMessage(`{"key":"go"}`),
+	PartitionKey:    "go",
+	Actor:           "hr-portal",
+}
+
+func TestEventJSON(t *testing.T) {
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "42",
+		"source": "/api/v1/skills",
+		"type": "skill.updated",
+		"subject": "go",
+		"time": "2026-10-19T09:00:00Z",
+		"datacontenttype": "application/json",
+		"dataschema": "urn:skillsapi:schema:skill.updated:v1",
+		"data": {"key": "go"},
+		"partitionkey": "go",
+		"actor": "hr-portal"
+	}`, string(b))
+}
+
+func TestMemory(t *testing.T) {
+	p := &Memory{}
+	require.NoError(t, p.Publish(context.Background(), event))
+	require.NoError(t, p.Publish(context.Background(), Event{ID: "43"}))
+
+	events := p.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "42", events[0].ID)
+	assert.Equal(t, "43", events[1].ID)
+
+	events[0].ID = "changed"
+	assert.Equal(t, "42", p.Events()[0].ID)
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	p := Log{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+	require.NoError(t, p.Publish(context.Background(), event))
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "Skill event", line["msg"])
+	assert.Equal(t, "skill.updated", line["type"])
+	assert.Equal(t, "go", line["subject"])
+	assert.Equal(t, "hr-portal", line["actor"])
+	assert.Equal(t, `{"key":"go"}`, line["data"])
+}
+
+func TestNATSMsg(t *testing.T) {
+	msg, err := natsMsg("skills.events", event)
+	require.NoError(t, err)
+
+	assert.Equal(t, "skills.events.skill.updated", msg.Subject)
+	assert.Equal(t, ContentType, msg.Header.Get("Content-Type"))
+	assert.Contains(t, string(msg.Data), `"specversion":"1.0"`)
+}
+
+func TestKafkaMessage(t *testing.T) {
+	msg, err := kafkaMessage(event)
+	require.NoError(t, err)
+
+	assert.Equal(t, "go", string(msg.Key))
+	assert.Equal(t, event.Time, msg.Time)
+	require.Len(t, msg.Headers, 1)
+	assert.Equal(t, "content-type", msg.Headers[0].Key)
+	assert.Equal(t, ContentType, string(msg.Headers[0].Value))
+	assert.Contains(t, string(msg.Value), `"specversion":"1.0"`)
+}
+
+func TestNew(t *testing.T) {
+	p, err := New(Config{})
+	require.NoError(t, err)
+	assert.IsType(t, Log{}, p)
+
+	p, err = New(Config{Kind: KindKafka, KafkaBrokers: []string{"localhost:9092"}})
+	require.NoError(t, err)
+	assert.Equal(t, "skills.events", p.(*Kafka).writer.Topic)
+	require.NoError(t, p.Close())
+
+	_, err = New(Config{Kind: KindKafka})
+	assert.EqualError(t, err, "the kafka publisher needs at least one broker")
+
+	_, err = New(Config{Kind: "rabbitmq"})
+	assert.EqualError(t, err, `unknown event publisher "rabbitmq"`)
+}
@@ -0,0 +1,57 @@
+package broker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	KindLog   = "log"
+	KindNATS  = "nats"
+	KindKafka = "kafka"
+
+	defaultSubject = "skills.events"
+)
+
+// Config selects and configures a Publisher. Kind defaults to KindLog.
+type Config struct {
+	Kind string
+
+	NATSURL       string
+	NATSSubject   string
+	NATSJetStream bool
+
+	KafkaBrokers []string
+	KafkaTopic   string
+}
+
+// New returns the Publisher selected by cfg. NATS defaults to the local
+// server and both brokers to the skills.events subject prefix or topic.
+func New(cfg Config) (Publisher, error) {
+	switch cfg.Kind {
+	case "", KindLog:
+		return Log{}, nil
+	case KindNATS:
+		url := cfg.NATSURL
+		if url == "" {
+			url = nats.DefaultURL
+		}
+		return NewNATS(url, withDefault(cfg.NATSSubject, defaultSubject), cfg.NATSJetStream)
+	case KindKafka:
+		if len(cfg.KafkaBrokers) == 0 {
+			return nil, errors.New("the kafka publisher needs at least one broker")
+		}
+		return NewKafka(cfg.KafkaBrokers, withDefault(cfg.KafkaTopic, defaultSubject)), nil
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q", cfg.Kind)
+	}
+}
+
+func withDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
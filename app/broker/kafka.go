@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Kafka publishes every event to Topic in structured content mode, keyed by
+// its partition key so that the events of a skill land on one partition and
+// stay in order. Publish waits for all in-sync replicas to store the event.
+type Kafka struct {
+	writer *kafka.Writer
+}
+
+func NewKafka(brokers []string, topic string) *Kafka {
+	return &Kafka{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		// Events are published one at a time; the default of a second
+		// would delay every one of them.
+		BatchTimeout: 10 * time.Millisecond,
+	}}
+}
+
+func (p *Kafka) Publish(ctx context.Context, e Event) error {
+	msg, err := kafkaMessage(e)
+	if err != nil {
+		return err
+	}
+	return p.writer.WriteMessages(ctx, msg)
+}
+
+func (p *Kafka) Close() error {
+	return p.writer.Close()
+}
+
+func kafkaMessage(e Event) (kafka.Message, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	return kafka.Message{
+		Key:     []byte(e.PartitionKey),
+		Value:   value,
+		Headers: []kafka.Header{{Key: "content-type", Value: []byte(ContentType)}},
+		Time:    e.Time,
+	}, nil
+}
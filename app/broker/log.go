@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log writes every event to Logger, or to the default logger when it is
+// nil.
+type Log struct {
+	Logger *slog.Logger
+}
+
+func (p Log) Publish(ctx context.Context, e Event) error {
+	logger := p.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.InfoContext(ctx, "Skill event",
+		"id", e.ID,
+		"type", e.Type,
+		"subject", e.Subject,
+		"actor", e.Actor,
+		"time", e.Time,
+		"data", string(e.Data),
+	)
+	return nil
+}
+
+func (p Log) Close() error {
+	return nil
+}
@@ -0,0 +1,32 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory keeps the events it is given, in order. It is meant for tests.
+type Memory struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (p *Memory) Publish(_ context.Context, e Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, e)
+	return nil
+}
+
+func (p *Memory) Close() error {
+	return nil
+}
+
+// Events returns a copy of the events published so far.
+func (p *Memory) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
@@ -0,0 +1,71 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATS publishes every event on SubjectPrefix.<type>, e.g.
+// skills.events.skill.updated, in structured content mode. With JetStream,
+// Publish waits for the stream to store the event and passes the event ID as
+// Nats-Msg-Id, so that a stream with a duplicate window drops events that are
+// published twice; otherwise it waits for the server to receive the event.
+type NATS struct {
+	SubjectPrefix string
+
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func NewNATS(url, subjectPrefix string, useJetStream bool) (*NATS, error) {
+	conn, err := nats.Connect(url, nats.Name("skillsapi"), nats.MaxReconnects(-1), nats.RetryOnFailedConnect(true))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &NATS{SubjectPrefix: subjectPrefix, conn: conn}
+	if useJetStream {
+		p.js, err = jetstream.New(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *NATS) Publish(ctx context.Context, e Event) error {
+	msg, err := natsMsg(p.SubjectPrefix, e)
+	if err != nil {
+		return err
+	}
+
+	if p.js != nil {
+		_, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(e.ID))
+		return err
+	}
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return p.conn.FlushWithContext(ctx)
+}
+
+// Close sends the events that are still buffered and disconnects.
+func (p *NATS) Close() error {
+	return p.conn.Drain()
+}
+
+func natsMsg(subjectPrefix string, e Event) (*nats.Msg, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := nats.NewMsg(subjectPrefix + "." + e.Type)
+	msg.Header.Set("Content-Type", ContentType)
+	msg.Data = data
+	return msg, nil
+}
@@ -0,0 +1,181 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriter(io.Discard) }}
+)
+
+// compressibleTypes are the media types worth compressing; a trailing slash
+// matches a whole top-level type.
+var compressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/yaml",
+	"application/x-ndjson",
+	"application/graphql-response+json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// Middleware compresses responses with brotli or gzip, whichever the client
+// prefers in its Accept-Encoding header. Bodies that are already encoded,
+// event streams and media types that do not compress well are passed through.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiate(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &writer{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = w
+		defer w.close()
+
+		c.Next()
+	}
+}
+
+// negotiate returns the preferred supported encoding of an Accept-Encoding
+// header, or "" when the response should not be encoded. Brotli wins ties.
+func negotiate(header string) string {
+	q := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = v
+				}
+			}
+		}
+		q[coding] = weight
+	}
+
+	weight := func(coding string) float64 {
+		if v, ok := q[coding]; ok {
+			return v
+		}
+		if v, ok := q["*"]; ok {
+			return v
+		}
+		return 0
+	}
+
+	br, gz := weight(encodingBrotli), weight(encodingGzip)
+	switch {
+	case br > 0 && br >= gz:
+		return encodingBrotli
+	case gz > 0:
+		return encodingGzip
+	}
+	return ""
+}
+
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range compressibleTypes {
+		if mediaType == t || strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t) {
+			return mediaType != "text/event-stream"
+		}
+	}
+	return false
+}
+
+// writer decides on the first write whether to encode the body, once the
+// handler has set the status and content type.
+type writer struct {
+	gin.ResponseWriter
+	encoding string
+	decided  bool
+	enc      io.WriteCloser
+}
+
+func (w *writer) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decide()
+	}
+	if w.enc == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.enc.Write(data)
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *writer) Flush() {
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *writer) decide() {
+	w.decided = true
+
+	h := w.Header()
+	status := w.Status()
+	if h.Get("Content-Encoding") != "" || status == http.StatusNoContent || status == http.StatusNotModified ||
+		!compressible(h.Get("Content-Type")) {
+		return
+	}
+
+	h.Set("Content-Encoding", w.encoding)
+	h.Del("Content-Length")
+	switch w.encoding {
+	case encodingBrotli:
+		bw := brotliWriters.Get().(*brotli.Writer)
+		bw.Reset(w.ResponseWriter)
+		w.enc = bw
+	default:
+		gw := gzipWriters.Get().(*gzip.Writer)
+		gw.Reset(w.ResponseWriter)
+		w.enc = gw
+	}
+}
+
+func (w *writer) close() {
+	if w.enc == nil {
+		return
+	}
+	_ = w.enc.Close()
+	switch enc := w.enc.(type) {
+	case *brotli.Writer:
+		brotliWriters.Put(enc)
+	case *gzip.Writer:
+		gzipWriters.Put(enc)
+	}
+	w.enc = nil
+}
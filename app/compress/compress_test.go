@@ -0,0 +1,114 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var body = strings.Repeat(`{"key":"go","name":"Go"},`, 100)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(body))
+	})
+	r.GET("/png", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(body))
+	})
+	r.GET("/events", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/event-stream", []byte(body))
+	})
+	r.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+	return r
+}
+
+func get(r *gin.Engine, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	r := newRouter()
+
+	t.Run("gzip", func(t *testing.T) {
+		rec := get(r, "/json", "gzip, deflate")
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Less(t, rec.Body.Len(), len(body))
+		zr, err := gzip.NewReader(rec.Body)
+		assert.NoError(t, err)
+		decoded, err := io.ReadAll(zr)
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("brotli", func(t *testing.T) {
+		rec := get(r, "/json", "gzip, br")
+
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		decoded, err := io.ReadAll(brotli.NewReader(rec.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		rec := get(r, "/json", "")
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, rec.Body.String())
+	})
+
+	t.Run("incompressible types are passed through", func(t *testing.T) {
+		for _, path := range []string{"/png", "/events"} {
+			rec := get(r, path, "gzip")
+
+			assert.Empty(t, rec.Header().Get("Content-Encoding"), path)
+			assert.Equal(t, body, rec.Body.String(), path)
+		}
+	})
+
+	t.Run("bodiless responses are passed through", func(t *testing.T) {
+		rec := get(r, "/empty", "gzip")
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Zero(t, rec.Body.Len())
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                      "",
+		"identity":              "",
+		"gzip":                  "gzip",
+		"br":                    "br",
+		"gzip, br":              "br",
+		"br;q=0.5, gzip":        "gzip",
+		"gzip;q=0, br;q=0":      "",
+		"*":                     "br",
+		"*;q=0.1, gzip;q=0.5":   "gzip",
+		"GZIP;Q=0.8, BR;q=0.2":  "gzip",
+		"deflate, gzip;q=0.001": "gzip",
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, negotiate(header), header)
+	}
+}
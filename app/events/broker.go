@@ -0,0 +1,113 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped; it can then resume from its last event ID.
+const subscriberBuffer = 64
+
+type Event struct {
+	ID   uint64
+	Type string
+	Key  string
+	Data interface{}
+}
+
+// Broker fans events out to in-process subscribers and keeps the most recent
+// ones so that a subscriber can resume after a reconnect. Event IDs start
+// from the broker's creation time in microseconds, so they keep increasing
+// across restarts.
+type Broker struct {
+	mu      sync.Mutex
+	nextID  uint64
+	history []Event
+	size    int
+	subs    map[chan Event]struct{}
+	closed  bool
+}
+
+// NewBroker returns a broker that keeps the last size events for replay.
+func NewBroker(size int) *Broker {
+	return &Broker{
+		nextID: uint64(time.Now().UnixMicro()),
+		size:   size,
+		subs:   map[chan Event]struct{}{},
+	}
+}
+
+// Publish assigns the event the next ID, records it and delivers it to every
+// subscriber. Subscribers that are too far behind are dropped rather than
+// blocking the publisher.
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	e.ID = b.nextID
+	if b.size > 0 {
+		if len(b.history) == b.size {
+			b.history = append(b.history[:0], b.history[1:]...)
+		}
+		b.history = append(b.history, e)
+	}
+
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+	return e
+}
+
+// Subscribe returns the recorded events after lastID, when lastID is not
+// zero, and a channel of the events published from now on. The channel is
+// closed when the subscriber falls behind, is cancelled or the broker is
+// closed.
+func (b *Broker) Subscribe(lastID uint64) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	if lastID != 0 {
+		for _, e := range b.history {
+			if e.ID > lastID {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return replay, ch, func() {}
+	}
+	b.subs[ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+	return replay, ch, cancel
+}
+
+// Close ends every subscription, for a graceful shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	t.Run("should deliver events to subscribers in order", func(t *testing.T) {
+		b := NewBroker(10)
+		_, ch, cancel := b.Subscribe(0)
+		defer cancel()
+
+		first := b.Publish(Event{Type: "skill.created", Key: "go"})
+		second := b.Publish(Event{Type: "skill.deleted", Key: "go"})
+
+		assert.Greater(t, second.ID, first.ID)
+		assert.Equal(t, first, <-ch)
+		assert.Equal(t, second, <-ch)
+	})
+
+	t.Run("should replay the events after the last event ID", func(t *testing.T) {
+		b := NewBroker(2)
+		first := b.Publish(Event{Key: "a"})
+		b.Publish(Event{Key: "b"})
+		b.Publish(Event{Key: "c"})
+
+		replay, _, cancel := b.Subscribe(first.ID)
+		defer cancel()
+		assert.Len(t, replay, 2)
+		assert.Equal(t, "b", replay[0].Key)
+		assert.Equal(t, "c", replay[1].Key)
+
+		replay, _, cancel = b.Subscribe(0)
+		defer cancel()
+		assert.Empty(t, replay)
+	})
+
+	t.Run("should drop a subscriber that falls behind", func(t *testing.T) {
+		b := NewBroker(0)
+		_, ch, cancel := b.Subscribe(0)
+		defer cancel()
+
+		for i := 0; i <= subscriberBuffer; i++ {
+			b.Publish(Event{Key: "go"})
+		}
+
+		n := 0
+		for range ch {
+			n++
+		}
+		assert.Equal(t, subscriberBuffer, n)
+	})
+
+	t.Run("should end subscriptions when closed", func(t *testing.T) {
+		b := NewBroker(0)
+		_, ch, cancel := b.Subscribe(0)
+		defer cancel()
+
+		b.Close()
+		_, ok := <-ch
+		assert.False(t, ok)
+
+		_, ch, _ = b.Subscribe(0)
+		_, ok = <-ch
+		assert.False(t, ok)
+	})
+}
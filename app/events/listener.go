@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	pingInterval         = 90 * time.Second
+)
+
+// Listen receives the Postgres notifications on channel and passes their
+// payloads to handle until ctx is cancelled. A lost connection is
+// re-established with exponential backoff; notifications sent while it was
+// down are lost, and handle is called with "" once it is back.
+func Listen(ctx context.Context, dsn, channel string, handle func(payload string)) error {
+	listener := pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventDisconnected:
+			slog.Warn("Lost the notification connection", "channel", channel, "error", err)
+		case pq.ListenerEventReconnected:
+			slog.Info("Re-established the notification connection", "channel", channel)
+		case pq.ListenerEventConnectionAttemptFailed:
+			slog.Warn("Failed to connect for notifications", "channel", channel, "error", err)
+		}
+	})
+	defer listener.Close()
+
+	// Listen blocks until the first connection is made; closing the listener
+	// on return unblocks it.
+	listening := make(chan error, 1)
+	go func() { listening <- listener.Listen(channel) }()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-listening:
+			if err != nil {
+				return err
+			}
+		case n := <-listener.Notify:
+			// A nil notification means the connection was re-established.
+			if n == nil {
+				handle("")
+				continue
+			}
+			handle(n.Extra)
+		case <-ping.C:
+			// Detects a dead connection that would otherwise go unnoticed.
+			go func() { _ = listener.Ping() }()
+		}
+	}
+}
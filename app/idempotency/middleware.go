@@ -0,0 +1,190 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"skillsapi/app/mtls"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// Middleware makes a write safe to retry when the client sends an
+// Idempotency-Key header. The first request with a key runs the handler and
+// stores its response for ttl; repeating the same request replays that
+// response, while reusing the key for a different request is rejected with a
+// 422. Keys are scoped to the client identity. Server errors are not stored,
+// so the request can be retried with the same key.
+func Middleware(db *sql.DB, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			abort(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abort(c, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		identity := c.GetString(mtls.IdentityKey)
+		hash := requestHash(c.Request.Method, c.Request.URL.Path, body)
+
+		claimed, err := claim(ctx, db, identity, key, hash, ttl)
+		if err != nil {
+			abort(c, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if !claimed {
+			replay(c, db, identity, key, hash)
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		completed := false
+		defer func() {
+			if !completed {
+				release(db, identity, key)
+			}
+		}()
+
+		c.Next()
+
+		if w.Status() >= http.StatusInternalServerError {
+			return
+		}
+		_, err = db.ExecContext(context.WithoutCancel(ctx), `UPDATE idempotency_keys
+			SET status_code = $1, content_type = $2, response_body = $3
+			WHERE identity = $4 AND key = $5`,
+			w.Status(), w.Header().Get("Content-Type"), w.body.Bytes(), identity, key)
+		if err != nil {
+			slog.Error("Failed to store idempotent response", "key", key, "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+func claim(ctx context.Context, db *sql.DB, identity, key, hash string, ttl time.Duration) (bool, error) {
+	_, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE identity = $1 AND key = $2 AND expires_at < now()`, identity, key)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := db.ExecContext(ctx, `INSERT INTO idempotency_keys (identity, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+		ON CONFLICT (identity, key) DO NOTHING`,
+		identity, key, hash, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+func replay(c *gin.Context, db *sql.DB, identity, key, hash string) {
+	var storedHash, contentType string
+	var status sql.NullInt64
+	var body []byte
+	err := db.QueryRowContext(c.Request.Context(), `SELECT request_hash, status_code, content_type, response_body
+		FROM idempotency_keys WHERE identity = $1 AND key = $2`, identity, key).
+		Scan(&storedHash, &status, &contentType, &body)
+	if err != nil {
+		abort(c, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	switch {
+	case storedHash != hash:
+		abort(c, http.StatusUnprocessableEntity, "Idempotency-Key has already been used for a different request")
+	case !status.Valid:
+		abort(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+	default:
+		c.Header(ReplayedHeader, "true")
+		c.Data(int(status.Int64), contentType, body)
+		c.Abort()
+	}
+}
+
+// release forgets a key whose request did not complete, so that it can be
+// retried. It runs after the handler, possibly while panicking, so it does not
+// use the request context.
+func release(db *sql.DB, identity, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE identity = $1 AND key = $2 AND status_code IS NULL`, identity, key)
+	if err != nil {
+		slog.Error("Failed to release idempotency key", "key", key, "error", err)
+	}
+}
+
+// PurgeExpired periodically deletes expired keys until ctx is cancelled.
+func PurgeExpired(ctx context.Context, db *sql.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at < now()`); err != nil {
+				slog.Error("Failed to purge expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
+
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func abort(c *gin.Context, code int, message string) {
+	c.AbortWithStatusJSON(code, gin.H{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"skillsapi/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func post(r *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRejectsOverlongKey(t *testing.T) {
+	r := gin.New()
+	r.POST("/things", Middleware(nil, time.Hour), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	w := post(r, "/things", strings.Repeat("k", 256), `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	calls := 0
+	r := gin.Default()
+	r.POST("/things", Middleware(db, time.Hour), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"status": "success", "call": calls})
+	})
+	r.POST("/flaky", Middleware(db, time.Hour), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Internal server error"})
+	})
+
+	t.Run("should replay the original response for the same request", func(t *testing.T) {
+		first := post(r, "/things", "key-1", `{"name":"a"}`)
+		second := post(r, "/things", "key-1", `{"name":"a"}`)
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(ReplayedHeader))
+		assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should reject reusing a key for a different payload", func(t *testing.T) {
+		w := post(r, "/things", "key-1", `{"name":"b"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should reject reusing a key for a different path", func(t *testing.T) {
+		w := post(r, "/flaky", "key-1", `{"name":"a"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should not store server errors", func(t *testing.T) {
+		post(r, "/flaky", "key-2", `{}`)
+		w := post(r, "/flaky", "key-2", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get(ReplayedHeader))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("should run every request without a key", func(t *testing.T) {
+		post(r, "/things", "", `{}`)
+		post(r, "/things", "", `{}`)
+
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("should run the request again once the key has expired", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE idempotency_keys SET expires_at = now() - interval '1 second' WHERE key = 'key-1'`)
+		assert.NoError(t, err)
+
+		w := post(r, "/things", "key-1", `{"name":"b"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 6, calls)
+	})
+}
@@ -0,0 +1,86 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+const IdentityKey = "identity"
+
+// IdentityMap maps a client certificate subject, as formatted by
+// pkix.Name.String (e.g. "CN=hr-portal,O=Acme"), to an identity name.
+type IdentityMap map[string]string
+
+func LoadIdentityMap(name string) (IdentityMap, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var m IdentityMap
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Resolve returns the identity of the verified client certificate of a
+// connection. Without a map the subject's common name is used; with a map,
+// subjects that are not listed are not authorised. A connection without a
+// client certificate resolves to "" and true.
+func (m IdentityMap) Resolve(state *tls.ConnectionState) (string, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", true
+	}
+
+	leaf := state.VerifiedChains[0][0]
+	if len(m) == 0 {
+		return leaf.Subject.CommonName, true
+	}
+	identity, ok := m[leaf.Subject.String()]
+	return identity, ok
+}
+
+// Identify resolves the verified client certificate of the request to an
+// identity. Subjects that the map does not authorise are rejected. Plain HTTP
+// requests and TLS requests without a client certificate pass through
+// unidentified.
+func Identify(m IdentityMap) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := m.Resolve(c.Request.TLS)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": "Client certificate is not authorised",
+			})
+			return
+		}
+
+		if identity != "" {
+			c.Set(IdentityKey, identity)
+		}
+		c.Next()
+	}
+}
+
+// RequireIdentity rejects requests from clients that are not identified, or
+// whose identity is not one of allowed. Without allowed identities, any
+// identified client is let through.
+func RequireIdentity(allowed ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity := c.GetString(IdentityKey)
+		if identity == "" || (len(allowed) > 0 && !slices.Contains(allowed, identity)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": "Forbidden",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,231 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) issue(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	require.NoError(t, os.WriteFile(name, data, 0o600))
+}
+
+func newTLSServer(t *testing.T, reloader *Reloader, identities IdentityMap) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Identify(identities))
+	r.GET("/whoami", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(IdentityKey))
+	})
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.TLS = reloader.TLSConfig()
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newClient(t *testing.T, ca *testCA, certPEM, keyPEM []byte) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+
+	cfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+
+	serverCert, serverKey := ca.issue(t, pkix.Name{CommonName: "skillsapi"}, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, serverCert)
+	writeFile(t, keyFile, serverKey)
+	writeFile(t, caFile, ca.pem)
+
+	reloader, err := NewReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	clientCert, clientKey := ca.issue(t, pkix.Name{CommonName: "hr-portal", Organization: []string{"Acme"}}, x509.ExtKeyUsageClientAuth)
+
+	t.Run("should map the client certificate common name to the identity", func(t *testing.T) {
+		srv := newTLSServer(t, reloader, nil)
+
+		resp, err := newClient(t, ca, clientCert, clientKey).Get(srv.URL + "/whoami")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "hr-portal", string(body))
+	})
+
+	t.Run("should reject clients without a certificate", func(t *testing.T) {
+		srv := newTLSServer(t, reloader, nil)
+
+		resp, err := newClient(t, ca, nil, nil).Get(srv.URL + "/whoami")
+		if err == nil {
+			resp.Body.Close()
+		}
+		assert.Error(t, err)
+	})
+
+	t.Run("should map the subject through the identity map", func(t *testing.T) {
+		srv := newTLSServer(t, reloader, IdentityMap{"CN=hr-portal,O=Acme": "hr"})
+
+		resp, err := newClient(t, ca, clientCert, clientKey).Get(srv.URL + "/whoami")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "hr", string(body))
+	})
+
+	t.Run("should forbid subjects missing from the identity map", func(t *testing.T) {
+		srv := newTLSServer(t, reloader, IdentityMap{"CN=learning,O=Acme": "learning"})
+
+		resp, err := newClient(t, ca, clientCert, clientKey).Get(srv.URL + "/whoami")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestReloaderPicksUpRotatedCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	oldCert, oldKey := ca.issue(t, pkix.Name{CommonName: "old"}, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, oldCert)
+	writeFile(t, keyFile, oldKey)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+	assert.False(t, reloader.changed())
+
+	newCert, newKey := ca.issue(t, pkix.Name{CommonName: "new"}, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, newCert)
+	writeFile(t, keyFile, newKey)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+
+	assert.True(t, reloader.changed())
+	require.NoError(t, reloader.Reload())
+
+	cfg, err := reloader.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "new", leaf.Subject.CommonName)
+	assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+}
+
+func TestRequireIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if id := c.GetHeader("X-Test-Identity"); id != "" {
+			c.Set(IdentityKey, id)
+		}
+	})
+	r.GET("/admin", RequireIdentity("ops"), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/write", RequireIdentity(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		path, identity string
+		code           int
+	}{
+		{"/admin", "ops", http.StatusNoContent},
+		{"/admin", "hr", http.StatusForbidden},
+		{"/admin", "", http.StatusForbidden},
+		{"/write", "hr", http.StatusNoContent},
+		{"/write", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("X-Test-Identity", tt.identity)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tt.code, w.Code, tt.path+" as "+tt.identity)
+	}
+}
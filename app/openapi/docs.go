@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// redoc is the Redoc bundle the documentation page runs, served from this API
+// so that the page works offline and loads no third-party script.
+//
+//go:generate curl -fsSL -o redoc.standalone.js https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js
+//go:embed redoc.standalone.js
+var redoc []byte
+
+func Spec() []byte {
+	return spec
+}
+
+func SetRouter(r *gin.Engine) {
+	r.GET("/openapi.json", GetSpec)
+	r.GET("/docs", GetDocs)
+	r.GET("/docs/redoc.standalone.js", GetDocsScript)
+}
+
+func GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", spec)
+}
+
+func GetDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
+
+func GetDocsScript(c *gin.Context) {
+	c.Data(http.StatusOK, "text/javascript; charset=utf-8", redoc)
+}
@@ -0,0 +1,96 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"skillsapi/app/openapi"
+	"skillsapi/app/person"
+	"skillsapi/app/skill"
+	"skillsapi/app/skillgraphql"
+	"skillsapi/app/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+func newRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	skill.SetRouter(r, &skill.Handler{})
+	webhook.SetRouter(r, &webhook.Handler{})
+	person.SetRouter(r, &person.Handler{})
+	openapi.SetRouter(r)
+
+	gql, err := skillgraphql.NewHandler(&skill.Storage{})
+	require.NoError(t, err)
+	skillgraphql.SetRouter(r, gql)
+	return r
+}
+
+func specOperations(t *testing.T) map[string]bool {
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &doc))
+	assert.True(t, strings.HasPrefix(doc.OpenAPI, "3.1"))
+
+	ops := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+			ops[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	return ops
+}
+
+func TestSpecCoversEveryRoute(t *testing.T) {
+	ops := specOperations(t)
+
+	routes := make(map[string]bool)
+	for _, route := range newRouter(t).Routes() {
+		op := route.Method + " " + pathParam.ReplaceAllString(route.Path, "{$1}")
+		routes[op] = true
+		assert.True(t, ops[op], "route %s is missing from openapi.json", op)
+	}
+
+	for op := range ops {
+		assert.True(t, routes[op], "openapi.json documents %s but no such route is registered", op)
+	}
+}
+
+func TestServeSpecAndDocs(t *testing.T) {
+	r := newRouter(t)
+
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, string(openapi.Spec()), w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/docs", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `spec-url="/openapi.json"`)
+	assert.Contains(t, w.Body.String(), `<script src="/docs/redoc.standalone.js">`)
+	assert.NotContains(t, w.Body.String(), "https://")
+
+	req, _ = http.NewRequest(http.MethodGet, "/docs/redoc.standalone.js", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.NotEmpty(t, w.Body.String())
+}
@@ -0,0 +1,5 @@
+// Placeholder for the Redoc v2.1.5 standalone bundle. Replace it with the
+// real bundle by running `go generate ./app/openapi`.
+document.querySelectorAll("redoc").forEach(function (el) {
+  el.textContent = "The Redoc bundle is missing: run go generate ./app/openapi and rebuild.";
+});
@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+type ValidatorOptions struct {
+	ValidateRequests  bool
+	ValidateResponses bool
+}
+
+var ginPathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+func LoadSpec() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Validator checks requests and, optionally, responses of every route that
+// is documented in openapi.json. Response validation buffers the whole body
+// and replaces non-conforming responses with a 500, so it is meant for tests.
+func Validator(opts ValidatorOptions) gin.HandlerFunc {
+	doc, err := LoadSpec()
+	if err != nil {
+		panic(err)
+	}
+
+	return func(c *gin.Context) {
+		route := findRoute(doc, c)
+		if route == nil || route.Operation == nil {
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams(c),
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if opts.ValidateRequests {
+			if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"status":  "error",
+					"message": "Invalid request payload",
+					"errors":  validationErrors(err),
+				})
+				return
+			}
+		}
+
+		if !opts.ValidateResponses {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		err := openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 w.Status(),
+			Header:                 w.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(w.body.Bytes())),
+			Options: &openapi3filter.Options{
+				MultiError:            true,
+				IncludeResponseStatus: true,
+				ExcludeResponseBody:   !canDecode(w.Header().Get("Content-Type")),
+			},
+		})
+		if err != nil {
+			w.Header().Del("Content-Length")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Response does not match the OpenAPI document",
+				"errors":  validationErrors(err),
+			})
+			return
+		}
+
+		if w.body.Len() > 0 {
+			_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		} else {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+	}
+}
+
+func findRoute(doc *openapi3.T, c *gin.Context) *routers.Route {
+	if c.FullPath() == "" {
+		return nil
+	}
+
+	path := ginPathParam.ReplaceAllString(c.FullPath(), "{$1}")
+	item := doc.Paths.Value(path)
+	if item == nil {
+		return nil
+	}
+
+	return &routers.Route{
+		Spec:      doc,
+		Path:      path,
+		PathItem:  item,
+		Method:    c.Request.Method,
+		Operation: item.GetOperation(c.Request.Method),
+	}
+}
+
+func pathParams(c *gin.Context) map[string]string {
+	params := make(map[string]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	return params
+}
+
+// canDecode reports whether kin-openapi can check a body of this type against
+// a schema; other types, such as NDJSON or HTML, are only checked for their
+// status and content type.
+func canDecode(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && openapi3filter.RegisteredBodyDecoder(mediaType) != nil
+}
+
+func validationErrors(err error) []string {
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, len(multi))
+	for _, e := range multi {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
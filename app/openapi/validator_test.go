@@ -0,0 +1,102 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"skillsapi/app/openapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecIsValid(t *testing.T) {
+	_, err := openapi.LoadSpec()
+	assert.NoError(t, err)
+}
+
+func TestValidatorRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateRequests: true}))
+	r.POST("/api/v1/skills", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "success"})
+	})
+
+	t.Run("should pass a valid request through", func(t *testing.T) {
+		body := `{"key":"go","name":"Go","description":"Go","logo":"go.svg","tags":["go"]}`
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/skills", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should reject a request that does not match the schema", func(t *testing.T) {
+		body := `{"key":"go","name":"Go","tags":"go"}`
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/skills", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response struct {
+			Status  string   `json:"status"`
+			Message string   `json:"message"`
+			Errors  []string `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "error", response.Status)
+		assert.Equal(t, "Invalid request payload", response.Message)
+		assert.NotEmpty(t, response.Errors)
+	})
+}
+
+func TestValidatorResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	r.GET("/api/v1/skills/:key", func(c *gin.Context) {
+		if c.Param("key") == "drift" {
+			c.JSON(http.StatusOK, gin.H{"status": "success", "data": gin.H{"key": "drift", "tags": "not a list"}})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": "Skill not found"})
+	})
+	r.GET("/undocumented", func(c *gin.Context) {
+		c.String(http.StatusTeapot, "short and stout")
+	})
+
+	t.Run("should pass a conforming response through", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/skills/unknown", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Skill not found"}`, w.Body.String())
+	})
+
+	t.Run("should replace a drifted response with an error", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/skills/drift", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Response does not match the OpenAPI document")
+	})
+
+	t.Run("should ignore routes missing from the document", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/undocumented", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Equal(t, "short and stout", w.Body.String())
+	})
+}
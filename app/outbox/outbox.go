@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"skillsapi/app/broker"
+	"skillsapi/app/skill"
+)
+
+// Message is a skill change recorded in the outbox by the transaction that
+// made it. Key is the skill key; messages with the same key are published in
+// the order of their IDs.
+type Message struct {
+	ID         int64
+	Key        string
+	Type       string
+	Payload    json.RawMessage
+	Actor      string
+	OccurredAt time.Time
+}
+
+// Event returns the CloudEvent of a message. Its ID is the message ID, so
+// a message that is published twice can be recognised by consumers.
+func (m Message) Event() (broker.Event, error) {
+	data, err := skill.EventData(m.Type, m.Payload)
+	if err != nil {
+		return broker.Event{}, err
+	}
+
+	return broker.Event{
+		SpecVersion:     broker.SpecVersion,
+		ID:              strconv.FormatInt(m.ID, 10),
+		Source:          skill.EventSource,
+		Type:            m.Type,
+		Subject:         m.Key,
+		Time:            m.OccurredAt,
+		DataContentType: "application/json",
+		DataSchema:      skill.EventDataSchema(m.Type),
+		Data:            data,
+		PartitionKey:    m.Key,
+		Actor:           m.Actor,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"skillsapi/app/broker"
+
+	"github.com/lib/pq"
+)
+
+// Relay drains the outbox into a broker. Every instance may run one; an
+// advisory lock lets only one of them drain at a time, which keeps the
+// messages of a key in order.
+type Relay struct {
+	Db        *sql.DB
+	Publisher broker.Publisher
+	BatchSize int
+}
+
+func NewRelay(db *sql.DB, publisher broker.Publisher) *Relay {
+	return &Relay{
+		Db:        db,
+		Publisher: publisher,
+		BatchSize: 100,
+	}
+}
+
+// Run drains the outbox every interval until ctx is cancelled. A full batch
+// is followed by the next one straight away.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := r.Drain(ctx)
+				if err != nil && ctx.Err() == nil {
+					slog.Error("Failed to relay skill events", "error", err)
+				}
+				if err != nil || n < r.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Drain publishes the oldest batch of messages in order and removes the ones
+// that were published. When a message fails, the later messages of its key
+// are held back until the next drain so that they are not published out of
+// order; messages of other keys still go out. It returns how many messages
+// were published and the first publication error.
+func (r *Relay) Drain(ctx context.Context) (int, error) {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('outbox'))`).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		// Another instance is draining.
+		return 0, nil
+	}
+
+	batch, err := r.next(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var published []int64
+	var firstErr error
+	held := map[string]bool{}
+	for _, m := range batch {
+		if held[m.Key] {
+			continue
+		}
+		if err := r.publish(ctx, m); err != nil {
+			held[m.Key] = true
+			if firstErr == nil {
+				firstErr = fmt.Errorf("publish outbox message %d: %w", m.ID, err)
+			}
+			continue
+		}
+		published = append(published, m.ID)
+	}
+
+	if len(published) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, pq.Array(published)); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(published), firstErr
+}
+
+func (r *Relay) publish(ctx context.Context, m Message) error {
+	e, err := m.Event()
+	if err != nil {
+		return err
+	}
+	return r.Publisher.Publish(ctx, e)
+}
+
+func (r *Relay) next(ctx context.Context, tx *sql.Tx) ([]Message, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, aggregate_key, event_type, payload, actor, occurred_at
+		FROM outbox ORDER BY id LIMIT $1`, r.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.Key, &m.Type, &m.Payload, &m.Actor, &m.OccurredAt); err != nil {
+			return nil, err
+		}
+		batch = append(batch, m)
+	}
+	return batch, rows.Err()
+}
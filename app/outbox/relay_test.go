@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"skillsapi/app/broker"
+	"skillsapi/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyPublisher fails every event about the subjects in failing.
+type flakyPublisher struct {
+	broker.Memory
+	failing map[string]bool
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, e broker.Event) error {
+	if p.failing[e.Subject] {
+		return errors.New("broker unavailable")
+	}
+	return p.Memory.Publish(ctx, e)
+}
+
+func outboxSize(t *testing.T, r *Relay) int {
+	var n int
+	require.NoError(t, r.Db.QueryRow(`SELECT count(*) FROM outbox`).Scan(&n))
+	return n
+}
+
+func TestRelay(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	ctx := context.Background()
+
+	for _, stmt := range []string{
+		`UPDATE skills SET name = 'Golang' WHERE key = 'go'`,
+		`UPDATE skills SET name = 'Node' WHERE key = 'nodejs'`,
+		`UPDATE skills SET name = 'Go' WHERE key = 'go'`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	t.Run("should hold back the later events of a key that fails", func(t *testing.T) {
+		p := &flakyPublisher{failing: map[string]bool{"go": true}}
+		r := NewRelay(db, p)
+
+		n, err := r.Drain(ctx)
+		assert.ErrorContains(t, err, "broker unavailable")
+		assert.Equal(t, 1, n)
+
+		events := p.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "nodejs", events[0].Subject)
+		assert.Equal(t, 2, outboxSize(t, r))
+	})
+
+	t.Run("should publish in order once the key recovers", func(t *testing.T) {
+		p := &broker.Memory{}
+		r := NewRelay(db, p)
+
+		n, err := r.Drain(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		events := p.Events()
+		require.Len(t, events, 2)
+		assert.Less(t, mustID(t, events[0]), mustID(t, events[1]))
+		assert.Equal(t, "1.0", events[0].SpecVersion)
+		assert.Equal(t, "skill.updated", events[0].Type)
+		assert.Equal(t, "/api/v1/skills", events[0].Source)
+		assert.Equal(t, "urn:skillsapi:schema:skill.updated:v1", events[0].DataSchema)
+		assert.Equal(t, "go", events[0].PartitionKey)
+		assert.JSONEq(t, `"Golang"`, string(mustField(t, events[0], "name")))
+		assert.JSONEq(t, `"Go"`, string(mustField(t, events[1], "name")))
+		assert.Equal(t, 0, outboxSize(t, r))
+	})
+
+	t.Run("should not publish changes that are rolled back", func(t *testing.T) {
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`DELETE FROM skills WHERE key = 'go'`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+
+		p := &broker.Memory{}
+		n, err := NewRelay(db, p).Drain(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("should record who deleted a skill", func(t *testing.T) {
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`SELECT set_config('skills.actor', 'hr-portal', true)`)
+		require.NoError(t, err)
+		_, err = tx.Exec(`DELETE FROM skills WHERE key = 'go'`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		p := &broker.Memory{}
+		_, err = NewRelay(db, p).Drain(ctx)
+		require.NoError(t, err)
+
+		events := p.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "skill.deleted", events[0].Type)
+		assert.Equal(t, "hr-portal", events[0].Actor)
+		assert.JSONEq(t, `{"key":"go","deleted_by":"hr-portal"}`, string(events[0].Data))
+	})
+}
+
+func mustField(t *testing.T, e broker.Event, name string) []byte {
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(e.Data, &fields))
+	return fields[name]
+}
+
+func mustID(t *testing.T, e broker.Event) int64 {
+	id, err := strconv.ParseInt(e.ID, 10, 64)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,189 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"skillsapi/app/skill"
+
+	"github.com/lib/pq"
+)
+
+// MaxEndorsementsPerDay is how many endorsements a person can give in any 24
+// hours.
+const MaxEndorsementsPerDay = 20
+
+var (
+	ErrEndorserNotFound    = errors.New("endorser not found")
+	ErrAlreadyEndorsed     = errors.New("skill already endorsed by the endorser")
+	ErrEndorsementLimit    = errors.New("daily endorsement limit reached")
+	ErrEndorsementNotFound = errors.New("endorsement not found")
+	ErrSelfEndorsement     = errors.New("people cannot endorse themselves")
+)
+
+// Endorsement is a colleague vouching for a skill a person claims.
+type Endorsement struct {
+	EndorserID   int64     `json:"endorser_id"`
+	EndorserName string    `json:"endorser_name"`
+	Comment      string    `json:"comment"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
+}
+
+// SkillStats sums up the people who claim a skill. AverageLevel is nil when
+// nobody does.
+type SkillStats struct {
+	Key          string       `json:"key"`
+	People       int          `json:"people"`
+	Interested   int          `json:"interested"`
+	AverageLevel *float64     `json:"average_level"`
+	Levels       []LevelCount `json:"levels"`
+	Endorsements int          `json:"endorsements"`
+}
+
+// LevelCount is how many people claim a skill at a level.
+type LevelCount struct {
+	Level  int `json:"level"`
+	People int `json:"people"`
+}
+
+// listEndorsements returns the endorsements of a claimed skill, oldest first.
+func listEndorsements(ctx context.Context, db *sql.DB, id int64, key string) ([]Endorsement, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM person_skills WHERE person_id = $1 AND skill_key = $2)`,
+		id, key).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrClaimNotFound
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT e.endorser_id, p.name, e.comment, e.created_at, e.created_by
+		FROM endorsements e JOIN people p ON p.id = e.endorser_id
+		WHERE e.person_id = $1 AND e.skill_key = $2 ORDER BY e.created_at, e.endorser_id`, id, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	endorsements := []Endorsement{}
+	for rows.Next() {
+		var e Endorsement
+		if err := rows.Scan(&e.EndorserID, &e.EndorserName, &e.Comment, &e.CreatedAt, &e.CreatedBy); err != nil {
+			return nil, err
+		}
+		endorsements = append(endorsements, e)
+	}
+	return endorsements, rows.Err()
+}
+
+// endorse records that e.EndorserID vouches for the skill a person claims.
+// The daily limit counts the endorser's endorsement_log, which withdrawing an
+// endorsement leaves alone. An advisory lock per endorser keeps concurrent
+// endorsements from going over MaxEndorsementsPerDay together.
+func endorse(ctx context.Context, db *sql.DB, id int64, key string, e Endorsement) (Endorsement, error) {
+	if e.EndorserID == id {
+		return Endorsement{}, ErrSelfEndorsement
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return Endorsement{}, err
+	}
+	defer tx.Rollback()
+
+	lock := "endorsements:" + strconv.FormatInt(e.EndorserID, 10)
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, lock); err != nil {
+		return Endorsement{}, err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM endorsement_log
+		WHERE endorser_id = $1 AND created_at <= now() - interval '1 day'`, e.EndorserID)
+	if err != nil {
+		return Endorsement{}, err
+	}
+	var given int
+	err = tx.QueryRowContext(ctx, `SELECT count(*) FROM endorsement_log WHERE endorser_id = $1`, e.EndorserID).Scan(&given)
+	if err != nil {
+		return Endorsement{}, err
+	}
+	if given >= MaxEndorsementsPerDay {
+		return Endorsement{}, ErrEndorsementLimit
+	}
+
+	err = tx.QueryRowContext(ctx, `WITH e AS (
+			INSERT INTO endorsements (person_id, skill_key, endorser_id, comment, created_by)
+			VALUES ($1, $2, $3, $4, $5) RETURNING endorser_id, comment, created_at, created_by
+		)
+		SELECT e.endorser_id, p.name, e.comment, e.created_at, e.created_by FROM e JOIN people p ON p.id = e.endorser_id`,
+		id, key, e.EndorserID, e.Comment, skill.ActorFrom(ctx)).
+		Scan(&e.EndorserID, &e.EndorserName, &e.Comment, &e.CreatedAt, &e.CreatedBy)
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &pqErr) && pqErr.Code == "23505":
+		return Endorsement{}, ErrAlreadyEndorsed
+	case errors.As(err, &pqErr) && pqErr.Constraint == "endorsements_claim_fkey":
+		return Endorsement{}, ErrClaimNotFound
+	case errors.As(err, &pqErr) && pqErr.Constraint == "endorsements_endorser_fkey":
+		return Endorsement{}, ErrEndorserNotFound
+	case err != nil:
+		return Endorsement{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO endorsement_log (endorser_id) VALUES ($1)`, e.EndorserID); err != nil {
+		return Endorsement{}, err
+	}
+	return e, tx.Commit()
+}
+
+func unendorse(ctx context.Context, db *sql.DB, id int64, key string, endorserID int64) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM endorsements WHERE person_id = $1 AND skill_key = $2 AND endorser_id = $3`,
+		id, key, endorserID)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrEndorsementNotFound
+	}
+	return nil
+}
+
+// skillStats sums up the claims and endorsements of a skill that exists.
+func skillStats(ctx context.Context, db *sql.DB, key string) (SkillStats, error) {
+	stats := SkillStats{Key: key, Levels: []LevelCount{}}
+	var average sql.NullFloat64
+	err := db.QueryRowContext(ctx, `SELECT count(*), count(*) FILTER (WHERE interested), avg(level)::float8,
+			(SELECT count(*) FROM endorsements WHERE skill_key = $1)
+		FROM person_skills WHERE skill_key = $1`, key).
+		Scan(&stats.People, &stats.Interested, &average, &stats.Endorsements)
+	if err != nil {
+		return SkillStats{}, err
+	}
+	if average.Valid {
+		stats.AverageLevel = &average.Float64
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT level, count(*) FROM person_skills
+		WHERE skill_key = $1 GROUP BY level ORDER BY level`, key)
+	if err != nil {
+		return SkillStats{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lc LevelCount
+		if err := rows.Scan(&lc.Level, &lc.People); err != nil {
+			return SkillStats{}, err
+		}
+		stats.Levels = append(stats.Levels, lc)
+	}
+	return stats, rows.Err()
+}
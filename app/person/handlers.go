@@ -0,0 +1,462 @@
+package person
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/skill"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavePerson struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email"`
+}
+
+type CreateEndorsement struct {
+	Comment string `json:"comment"`
+}
+
+type SavePersonSkill struct {
+	Level      int     `json:"level" binding:"required,min=1"`
+	LastUsed   *string `json:"last_used"`
+	Interested bool    `json:"interested"`
+}
+
+// GetPeople lists everyone, or with ?skill= the people who claim a skill,
+// optionally at ?min_level= or above.
+func (h *Handler) GetPeople(c *gin.Context) {
+	key := c.Query("skill")
+	minLevel := 1
+	if s := c.Query("min_level"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || key == "" {
+			respondError(c, http.StatusBadRequest, "Invalid min_level parameter")
+			return
+		}
+		minLevel = n
+	}
+
+	if key == "" {
+		people, err := listPeople(c.Request.Context(), h.Db)
+		for i := range people {
+			hideEmail(c, &people[i])
+		}
+		respondList(c, people, err)
+		return
+	}
+
+	canonical, err := (&skill.Storage{Db: h.Db}).CanonicalKey(c.Request.Context(), key)
+	if err != nil && !errors.Is(err, skill.ErrSkillNotFound) {
+		internalError(c)
+		return
+	}
+	if canonical != "" {
+		key = canonical
+	}
+	profiles, err := listPeopleWithSkill(c.Request.Context(), h.Db, key, minLevel)
+	for i := range profiles {
+		hideEmail(c, &profiles[i].Person)
+	}
+	respondList(c, profiles, err)
+}
+
+func respondList(c *gin.Context, data interface{}, err error) {
+	if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetPerson returns the profile of a person, with the skills they claim.
+func (h *Handler) GetPerson(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := getProfile(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+	hideEmail(c, &profile.Person)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   profile,
+	})
+}
+
+// CreatePerson adds the profile of the client making the request.
+func (h *Handler) CreatePerson(c *gin.Context) {
+	var req SavePerson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	created, err := createPerson(skill.RequestContext(c), h.Db, Person{Name: req.Name, Email: req.Email})
+	if errors.Is(err, ErrProfileExists) {
+		respondError(c, http.StatusConflict, "A profile already exists for this identity")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   created,
+	})
+}
+
+func (h *Handler) UpdatePerson(c *gin.Context) {
+	id, ok := h.ownedID(c)
+	if !ok {
+		return
+	}
+	var req SavePerson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	updated, err := updatePerson(skill.RequestContext(c), h.Db, id, Person{Name: req.Name, Email: req.Email})
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   updated,
+	})
+}
+
+func (h *Handler) DeletePerson(c *gin.Context) {
+	id, ok := h.ownedID(c)
+	if !ok {
+		return
+	}
+
+	err := deletePerson(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Person deleted",
+	})
+}
+
+// PutPersonSkill records a person's own assessment of a skill. The level must
+// be on the skill's ladder, or at most skill.MaxLevels when it has none.
+func (h *Handler) PutPersonSkill(c *gin.Context) {
+	id, ok := h.ownedID(c)
+	if !ok {
+		return
+	}
+	var req SavePersonSkill
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if req.LastUsed != nil {
+		lastUsed, err := time.Parse(time.DateOnly, *req.LastUsed)
+		if err != nil || lastUsed.After(time.Now()) {
+			respondError(c, http.StatusBadRequest, "Invalid last_used date")
+			return
+		}
+	}
+
+	skills := &skill.Storage{Db: h.Db}
+	target, err := skills.ResolveSkill(c.Request.Context(), c.Param("key"))
+	if errors.Is(err, skill.ErrSkillNotFound) {
+		respondError(c, http.StatusNotFound, "Skill not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+	rubric, err := skills.Levels(c.Request.Context(), target.Key)
+	if err != nil && !errors.Is(err, skill.ErrSkillNotFound) {
+		internalError(c)
+		return
+	}
+	top := len(rubric.Levels)
+	if top == 0 {
+		top = skill.MaxLevels
+	}
+	if req.Level > top {
+		respondError(c, http.StatusBadRequest, "Level is not on the skill's ladder")
+		return
+	}
+
+	saved, err := putPersonSkill(skill.RequestContext(c), h.Db, id, PersonSkill{
+		Key:        target.Key,
+		Level:      req.Level,
+		LastUsed:   req.LastUsed,
+		Interested: req.Interested,
+	})
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return
+	} else if errors.Is(err, skill.ErrSkillNotFound) {
+		respondError(c, http.StatusNotFound, "Skill not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   saved,
+	})
+}
+
+func (h *Handler) DeletePersonSkill(c *gin.Context) {
+	id, ok := h.ownedID(c)
+	if !ok {
+		return
+	}
+
+	err := deletePersonSkill(c.Request.Context(), h.Db, id, h.skillKey(c))
+	if errors.Is(err, ErrClaimNotFound) {
+		respondError(c, http.StatusNotFound, "Skill not on the person's profile")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Skill removed from the profile",
+	})
+}
+
+func (h *Handler) GetEndorsements(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	endorsements, err := listEndorsements(c.Request.Context(), h.Db, id, h.skillKey(c))
+	if errors.Is(err, ErrClaimNotFound) {
+		respondError(c, http.StatusNotFound, "Skill not on the person's profile")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   endorsements,
+	})
+}
+
+// Endorse lets a colleague vouch for a skill on someone's profile, once per
+// skill and at most MaxEndorsementsPerDay times a day. The endorser is the
+// person whose profile the client created.
+func (h *Handler) Endorse(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var req CreateEndorsement
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	endorserID, ok := h.endorserID(c)
+	if !ok {
+		return
+	}
+
+	endorsement, err := endorse(skill.RequestContext(c), h.Db, id, h.skillKey(c), Endorsement{
+		EndorserID: endorserID,
+		Comment:    req.Comment,
+	})
+	switch {
+	case errors.Is(err, ErrSelfEndorsement):
+		respondError(c, http.StatusBadRequest, "People cannot endorse themselves")
+	case errors.Is(err, ErrEndorserNotFound):
+		respondError(c, http.StatusForbidden, "Endorser not found")
+	case errors.Is(err, ErrClaimNotFound):
+		respondError(c, http.StatusNotFound, "Skill not on the person's profile")
+	case errors.Is(err, ErrAlreadyEndorsed):
+		respondError(c, http.StatusConflict, "Skill already endorsed by this endorser")
+	case errors.Is(err, ErrEndorsementLimit):
+		respondError(c, http.StatusTooManyRequests, "Daily endorsement limit reached")
+	case err != nil:
+		internalError(c)
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   endorsement,
+		})
+	}
+}
+
+// Unendorse withdraws an endorsement. Only the endorser can withdraw it.
+func (h *Handler) Unendorse(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	endorserID, err := strconv.ParseInt(c.Param("endorser_id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusNotFound, "Endorsement not found")
+		return
+	}
+	caller, ok := h.endorserID(c)
+	if !ok {
+		return
+	}
+	if caller != endorserID {
+		respondError(c, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	err = unendorse(c.Request.Context(), h.Db, id, h.skillKey(c), endorserID)
+	if errors.Is(err, ErrEndorsementNotFound) {
+		respondError(c, http.StatusNotFound, "Endorsement not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Endorsement withdrawn",
+	})
+}
+
+// GetSkillStats sums up who claims a skill, at which levels, and how often
+// they are endorsed.
+func (h *Handler) GetSkillStats(c *gin.Context) {
+	target, err := (&skill.Storage{Db: h.Db}).ResolveSkill(c.Request.Context(), c.Param("key"))
+	if errors.Is(err, skill.ErrSkillNotFound) {
+		respondError(c, http.StatusNotFound, "Skill not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	stats, err := skillStats(c.Request.Context(), h.Db, target.Key)
+	if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   stats,
+	})
+}
+
+// skillKey returns the key of the skill named by the key path parameter,
+// which may be an alias. An unknown name is returned as is.
+func (h *Handler) skillKey(c *gin.Context) string {
+	key := c.Param("key")
+	if canonical, err := (&skill.Storage{Db: h.Db}).CanonicalKey(c.Request.Context(), key); err == nil {
+		return canonical
+	}
+	return key
+}
+
+// ownedID reads the id of a person whose profile the client may change: its
+// own, or any when the client is an admin.
+func (h *Handler) ownedID(c *gin.Context) (int64, bool) {
+	id, ok := pathID(c)
+	if !ok {
+		return 0, false
+	}
+
+	identity := c.GetString(mtls.IdentityKey)
+	if slices.Contains(h.Admins, identity) {
+		return id, true
+	}
+	owner, err := personIdentity(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return 0, false
+	} else if err != nil {
+		internalError(c)
+		return 0, false
+	}
+	if identity == "" || owner != identity {
+		respondError(c, http.StatusForbidden, "Forbidden")
+		return 0, false
+	}
+	return id, true
+}
+
+// endorserID returns the id of the profile the calling client owns, which is
+// who it endorses as.
+func (h *Handler) endorserID(c *gin.Context) (int64, bool) {
+	id, err := personByIdentity(c.Request.Context(), h.Db, c.GetString(mtls.IdentityKey))
+	if errors.Is(err, ErrPersonNotFound) {
+		respondError(c, http.StatusForbidden, "Endorser not found")
+		return 0, false
+	} else if err != nil {
+		internalError(c)
+		return 0, false
+	}
+	return id, true
+}
+
+// hideEmail keeps a person's email from clients that are not identified.
+func hideEmail(c *gin.Context, p *Person) {
+	if c.GetString(mtls.IdentityKey) == "" {
+		p.Email = ""
+	}
+}
+
+// pathID reads the person id; anything but a number cannot name a person, so
+// it is answered with a 404.
+func pathID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusNotFound, "Person not found")
+		return 0, false
+	}
+	return id, true
+}
+
+func respondError(c *gin.Context, code int, message string) {
+	c.JSON(code, gin.H{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+func internalError(c *gin.Context) {
+	respondError(c, http.StatusInternalServerError, "Internal server error")
+}
@@ -0,0 +1,315 @@
+package person
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
+	"skillsapi/app/skill"
+	"skillsapi/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIdentityHeader names the client identity of a test request, which
+// mutual TLS would otherwise establish.
+const testIdentityHeader = "X-Test-Identity"
+
+func newTestRouter(h *Handler) *gin.Engine {
+	r := gin.New()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	r.Use(func(c *gin.Context) {
+		if identity := c.GetHeader(testIdentityHeader); identity != "" {
+			c.Set(mtls.IdentityKey, identity)
+		}
+	})
+	SetRouter(r, h)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	return serveAs(r, "", method, path, body)
+}
+
+// serveAs sends a request from the client with the given identity.
+func serveAs(r *gin.Engine, identity, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(testIdentityHeader, identity)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPersonSkillValidation(t *testing.T) {
+	r := newTestRouter(&Handler{Admins: []string{"hr"}})
+
+	tests := map[string]string{
+		`{}`:                                   "Invalid request payload",
+		`{"level":0}`:                          "Invalid request payload",
+		`{"level":2,"last_used":"yesterday"}`:  "Invalid last_used date",
+		`{"level":2,"last_used":"2999-01-01"}`: "Invalid last_used date",
+	}
+	for body, message := range tests {
+		w := serveAs(r, "hr", http.MethodPut, "/api/v1/people/1/skills/go", body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.JSONEq(t, fmt.Sprintf(`{"status":"error","message":%q}`, message), w.Body.String(), body)
+	}
+
+	w := serve(r, http.MethodPut, "/api/v1/people/1/skills/go", `{"level":2}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serve(r, http.MethodGet, "/api/v1/people?min_level=2", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(r, http.MethodGet, "/api/v1/people/abc", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPeople(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	r := newTestRouter(&Handler{Db: db, Admins: []string{"hr"}})
+	skills := gin.New()
+	skill.SetRouter(skills, &skill.Handler{Db: db})
+
+	data := func(w *httptest.ResponseRecorder, v interface{}) {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NoError(t, json.Unmarshal(response.Data, v))
+	}
+	// create adds the profile of the client identified as the lower-cased
+	// name.
+	create := func(name string) int64 {
+		var p Person
+		data(serveAs(r, strings.ToLower(name), http.MethodPost, "/api/v1/people", fmt.Sprintf(`{"name":%q,"email":"%s@example.com"}`, name, strings.ToLower(name))), &p)
+		return p.ID
+	}
+
+	require.Equal(t, http.StatusOK, serve(skills, http.MethodPost, "/api/v1/skills/go/aliases", `{"alias":"golang"}`).Code)
+	require.Equal(t, http.StatusOK, serve(skills, http.MethodPost, "/api/v1/skills", `{"key":"rust","name":"Rust","description":"","logo":"","tags":[]}`).Code)
+	require.Equal(t, http.StatusOK, serve(skills, http.MethodPut, "/api/v1/skills/rust/levels", `{"levels":[{"level":1,"name":"Novice"},{"level":2,"name":"Proficient"}]}`).Code)
+	ann, bob := create("Ann"), create("Bob")
+
+	t.Run("should give each identity one profile", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPost, "/api/v1/people", `{"name":"Nobody"}`).Code)
+
+		w := serveAs(r, "ann", http.MethodPost, "/api/v1/people", `{"name":"Ann again"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"A profile already exists for this identity"}`, w.Body.String())
+	})
+
+	t.Run("should claim skills on a profile", func(t *testing.T) {
+		var ps PersonSkill
+		data(serveAs(r, "ann", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/golang", ann), `{"level":4,"last_used":"2026-09-30","interested":true}`), &ps)
+		assert.Equal(t, "go", ps.Key)
+		assert.Equal(t, "Go", ps.Name)
+		require.NotNil(t, ps.LastUsed)
+		assert.Equal(t, "2026-09-30", *ps.LastUsed)
+
+		data(serveAs(r, "bob", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/go", bob), `{"level":2}`), &ps)
+		assert.Nil(t, ps.LastUsed)
+		data(serveAs(r, "ann", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/rust", ann), `{"level":2}`), &ps)
+
+		var profile Profile
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		assert.Equal(t, "Ann", profile.Name)
+		require.Len(t, profile.Skills, 2)
+		assert.Equal(t, "go", profile.Skills[0].Key)
+		assert.True(t, profile.Skills[0].Interested)
+	})
+
+	t.Run("should only let owners and admins change a profile", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/people/%d", ann)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "bob", http.MethodPut, path+"/skills/go", `{"level":1}`).Code)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "bob", http.MethodDelete, path+"/skills/go", "").Code)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "bob", http.MethodPut, path, `{"name":"Ann"}`).Code)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "bob", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusForbidden, serve(r, http.MethodPut, path, `{"name":"Ann"}`).Code)
+
+		var p Person
+		data(serveAs(r, "hr", http.MethodPut, path, `{"name":"Ann","email":"ann@example.org"}`), &p)
+		assert.Equal(t, "ann@example.org", p.Email)
+		assert.Equal(t, "hr", p.UpdatedBy)
+	})
+
+	t.Run("should only show emails to identified clients", func(t *testing.T) {
+		var profile Profile
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		assert.Empty(t, profile.Email)
+		data(serveAs(r, "bob", http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		assert.Equal(t, "ann@example.org", profile.Email)
+
+		var people []Person
+		data(serve(r, http.MethodGet, "/api/v1/people", ""), &people)
+		require.Len(t, people, 2)
+		assert.Empty(t, people[0].Email)
+	})
+
+	t.Run("should keep levels on the skill's ladder", func(t *testing.T) {
+		w := serveAs(r, "bob", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/rust", bob), `{"level":3}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = serveAs(r, "bob", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/go", bob), `{"level":11}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = serveAs(r, "bob", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/nope", bob), `{"level":1}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveAs(r, "bob", http.MethodPut, "/api/v1/people/999/skills/go", `{"level":1}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should find people by skill and minimum level", func(t *testing.T) {
+		var people []Profile
+		data(serve(r, http.MethodGet, "/api/v1/people?skill=golang", ""), &people)
+		require.Len(t, people, 2)
+		assert.Equal(t, []int64{ann, bob}, []int64{people[0].ID, people[1].ID})
+		assert.Equal(t, 4, people[0].Skills[0].Level)
+
+		data(serve(r, http.MethodGet, "/api/v1/people?skill=go&min_level=3", ""), &people)
+		require.Len(t, people, 1)
+		assert.Equal(t, ann, people[0].ID)
+
+		data(serve(r, http.MethodGet, "/api/v1/people?skill=nope", ""), &people)
+		assert.Empty(t, people)
+	})
+
+	t.Run("should follow the skill when it is renamed or deleted", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(skills, http.MethodPost, "/api/v1/skills/rust/actions/rename", `{"key":"rust-lang"}`).Code)
+
+		var profile Profile
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		require.Len(t, profile.Skills, 2)
+		assert.Equal(t, "rust-lang", profile.Skills[1].Key)
+
+		require.Equal(t, http.StatusOK, serve(skills, http.MethodDelete, "/api/v1/skills/rust-lang", "").Code)
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		assert.Len(t, profile.Skills, 1)
+	})
+
+	t.Run("should remove skills and people", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/people/%d/skills/go", bob)
+		assert.Equal(t, http.StatusOK, serveAs(r, "bob", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusNotFound, serveAs(r, "bob", http.MethodDelete, path, "").Code)
+
+		assert.Equal(t, http.StatusOK, serveAs(r, "ann", http.MethodDelete, fmt.Sprintf("/api/v1/people/%d", ann), "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), "").Code)
+		assert.Equal(t, http.StatusNotFound, serveAs(r, "ann", http.MethodDelete, fmt.Sprintf("/api/v1/people/%d", ann), "").Code)
+
+		var people []Person
+		data(serve(r, http.MethodGet, "/api/v1/people", ""), &people)
+		require.Len(t, people, 1)
+		assert.Equal(t, bob, people[0].ID)
+	})
+}
+
+func TestEndorsements(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	r := newTestRouter(&Handler{Db: db})
+
+	data := func(w *httptest.ResponseRecorder, v interface{}) {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NoError(t, json.Unmarshal(response.Data, v))
+	}
+	create := func(name string) int64 {
+		var p Person
+		data(serveAs(r, name, http.MethodPost, "/api/v1/people", fmt.Sprintf(`{"name":%q}`, name)), &p)
+		return p.ID
+	}
+	// endorse sends an endorsement from the client identified as endorser.
+	endorse := func(id int64, endorser string) *httptest.ResponseRecorder {
+		return serveAs(r, endorser, http.MethodPost, fmt.Sprintf("/api/v1/people/%d/skills/go/endorsements", id),
+			`{"comment":"Solid reviews"}`)
+	}
+
+	ann, bob, eve := create("Ann"), create("Bob"), create("Eve")
+	var ps PersonSkill
+	data(serveAs(r, "Ann", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/go", ann), `{"level":3,"interested":true}`), &ps)
+	data(serveAs(r, "Bob", http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/go", bob), `{"level":1}`), &ps)
+
+	t.Run("should endorse a claimed skill once", func(t *testing.T) {
+		var e Endorsement
+		data(endorse(ann, "Bob"), &e)
+		assert.Equal(t, bob, e.EndorserID)
+		assert.Equal(t, "Bob", e.EndorserName)
+		assert.Equal(t, "Solid reviews", e.Comment)
+		data(endorse(ann, "Eve"), &e)
+
+		assert.Equal(t, http.StatusConflict, endorse(ann, "Bob").Code)
+		assert.Equal(t, http.StatusBadRequest, endorse(ann, "Ann").Code)
+		assert.Equal(t, http.StatusNotFound, endorse(eve, "Ann").Code)
+		assert.Equal(t, http.StatusForbidden, endorse(ann, "").Code)
+
+		w := endorse(ann, "Nobody")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Endorser not found"}`, w.Body.String())
+
+		var endorsements []Endorsement
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d/skills/go/endorsements", ann), ""), &endorsements)
+		assert.Len(t, endorsements, 2)
+	})
+
+	t.Run("should count endorsements on the profile and the skill", func(t *testing.T) {
+		var profile Profile
+		data(serve(r, http.MethodGet, fmt.Sprintf("/api/v1/people/%d", ann), ""), &profile)
+		require.Len(t, profile.Skills, 1)
+		assert.Equal(t, 2, profile.Skills[0].Endorsements)
+
+		var stats SkillStats
+		data(serve(r, http.MethodGet, "/api/v1/skills/go/stats", ""), &stats)
+		assert.Equal(t, 2, stats.People)
+		assert.Equal(t, 1, stats.Interested)
+		assert.Equal(t, 2, stats.Endorsements)
+		require.NotNil(t, stats.AverageLevel)
+		assert.Equal(t, 2.0, *stats.AverageLevel)
+		assert.Equal(t, []LevelCount{{Level: 1, People: 1}, {Level: 3, People: 1}}, stats.Levels)
+
+		assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/api/v1/skills/nope/stats", "").Code)
+	})
+
+	t.Run("should withdraw an endorsement", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/people/%d/skills/go/endorsements/%d", ann, eve)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "Bob", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusForbidden, serveAs(r, "Ann", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusForbidden, serve(r, http.MethodDelete, path, "").Code)
+
+		assert.Equal(t, http.StatusOK, serveAs(r, "Eve", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusNotFound, serveAs(r, "Eve", http.MethodDelete, path, "").Code)
+	})
+
+	t.Run("should cap endorsements given per day", func(t *testing.T) {
+		// Eve's withdrawn endorsement still counts.
+		var colleague int64
+		for i := 1; i < MaxEndorsementsPerDay; i++ {
+			name := fmt.Sprintf("Colleague %d", i)
+			colleague = create(name)
+			data(serveAs(r, name, http.MethodPut, fmt.Sprintf("/api/v1/people/%d/skills/go", colleague), `{"level":1}`), &ps)
+			w := endorse(colleague, "Eve")
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+		assert.Equal(t, http.StatusTooManyRequests, endorse(bob, "Eve").Code)
+
+		path := fmt.Sprintf("/api/v1/people/%d/skills/go/endorsements/%d", colleague, eve)
+		require.Equal(t, http.StatusOK, serveAs(r, "Eve", http.MethodDelete, path, "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, endorse(colleague, "Eve").Code)
+	})
+}
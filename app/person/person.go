@@ -0,0 +1,46 @@
+package person
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Person is someone whose skills are recorded. Email is only shown to
+// identified clients.
+type Person struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// Profile is a person with the skills they claim: all of them on their
+// profile, or the matching one when people are searched by skill.
+type Profile struct {
+	Person
+	Skills []PersonSkill `json:"skills"`
+}
+
+// PersonSkill is a skill a person claims, at a level of the skill's ladder.
+// LastUsed is a date such as 2026-09-30, or nil when not given. Endorsements
+// counts the colleagues who vouch for the claim.
+type PersonSkill struct {
+	Key          string    `json:"key"`
+	Name         string    `json:"name"`
+	Level        int       `json:"level"`
+	LastUsed     *string   `json:"last_used"`
+	Interested   bool      `json:"interested"`
+	Endorsements int       `json:"endorsements"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UpdatedBy    string    `json:"updated_by"`
+}
+
+type Handler struct {
+	Db *sql.DB
+	// Admins are the client identities allowed to change every profile.
+	// Other clients can only change the profile they created.
+	Admins []string
+}
@@ -0,0 +1,28 @@
+package person
+
+import (
+	"time"
+
+	"skillsapi/app/idempotency"
+	"skillsapi/app/mtls"
+
+	"github.com/gin-gonic/gin"
+)
+
+const idempotencyTTL = 24 * time.Hour
+
+func SetRouter(r *gin.Engine, h *Handler) {
+	identified := mtls.RequireIdentity()
+
+	r.GET("/api/v1/people", h.GetPeople)
+	r.GET("/api/v1/people/:id", h.GetPerson)
+	r.POST("/api/v1/people", identified, idempotency.Middleware(h.Db, idempotencyTTL), h.CreatePerson)
+	r.PUT("/api/v1/people/:id", identified, h.UpdatePerson)
+	r.DELETE("/api/v1/people/:id", identified, h.DeletePerson)
+	r.PUT("/api/v1/people/:id/skills/:key", identified, h.PutPersonSkill)
+	r.DELETE("/api/v1/people/:id/skills/:key", identified, h.DeletePersonSkill)
+	r.GET("/api/v1/people/:id/skills/:key/endorsements", h.GetEndorsements)
+	r.POST("/api/v1/people/:id/skills/:key/endorsements", identified, idempotency.Middleware(h.Db, idempotencyTTL), h.Endorse)
+	r.DELETE("/api/v1/people/:id/skills/:key/endorsements/:endorser_id", identified, h.Unendorse)
+	r.GET("/api/v1/skills/:key/stats", h.GetSkillStats)
+}
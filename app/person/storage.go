@@ -0,0 +1,220 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"skillsapi/app/skill"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrPersonNotFound = errors.New("person not found")
+	ErrClaimNotFound  = errors.New("person does not claim the skill")
+	ErrProfileExists  = errors.New("identity already has a profile")
+)
+
+const personColumns = `id, name, email, created_at, created_by, updated_at, updated_by`
+
+// personSkillColumns are read from person_skills joined as ps with skills
+// joined as s.
+const personSkillColumns = `ps.skill_key, s.name, ps.level, ps.last_used, ps.interested,
+	(SELECT count(*) FROM endorsements e WHERE e.person_id = ps.person_id AND e.skill_key = ps.skill_key),
+	ps.updated_at, ps.updated_by`
+
+func scanPerson(row skill.Scanner, extra ...interface{}) (Person, error) {
+	var p Person
+	err := row.Scan(append([]interface{}{&p.ID, &p.Name, &p.Email, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy}, extra...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Person{}, ErrPersonNotFound
+	}
+	return p, err
+}
+
+// personSkillFields returns the scan destinations of personSkillColumns. The
+// date is only set on ps once the row has been scanned, by calling done.
+func personSkillFields(ps *PersonSkill) (fields []interface{}, done func()) {
+	var lastUsed sql.NullTime
+	return []interface{}{&ps.Key, &ps.Name, &ps.Level, &lastUsed, &ps.Interested, &ps.Endorsements, &ps.UpdatedAt, &ps.UpdatedBy}, func() {
+		ps.LastUsed = nil
+		if lastUsed.Valid {
+			date := lastUsed.Time.Format(time.DateOnly)
+			ps.LastUsed = &date
+		}
+	}
+}
+
+// createPerson adds the profile of the client identity the context is
+// attributed to. An identity has one profile.
+func createPerson(ctx context.Context, db *sql.DB, p Person) (Person, error) {
+	row := db.QueryRowContext(ctx, `INSERT INTO people (name, email, identity, created_by, updated_by)
+		VALUES ($1, $2, $3, $3, $3) RETURNING `+personColumns, p.Name, p.Email, skill.ActorFrom(ctx))
+	created, err := scanPerson(row)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return Person{}, ErrProfileExists
+	}
+	return created, err
+}
+
+// personIdentity returns the client identity that owns a person's profile.
+func personIdentity(ctx context.Context, db *sql.DB, id int64) (string, error) {
+	var identity string
+	err := db.QueryRowContext(ctx, `SELECT identity FROM people WHERE id = $1`, id).Scan(&identity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrPersonNotFound
+	}
+	return identity, err
+}
+
+// personByIdentity returns the id of the profile a client identity owns.
+func personByIdentity(ctx context.Context, db *sql.DB, identity string) (int64, error) {
+	var id int64
+	err := db.QueryRowContext(ctx, `SELECT id FROM people WHERE identity = $1`, identity).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrPersonNotFound
+	}
+	return id, err
+}
+
+func updatePerson(ctx context.Context, db *sql.DB, id int64, p Person) (Person, error) {
+	row := db.QueryRowContext(ctx, `UPDATE people SET name = $1, email = $2, updated_at = now(), updated_by = $3
+		WHERE id = $4 RETURNING `+personColumns, p.Name, p.Email, skill.ActorFrom(ctx), id)
+	return scanPerson(row)
+}
+
+func deletePerson(ctx context.Context, db *sql.DB, id int64) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM people WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPersonNotFound
+	}
+	return nil
+}
+
+// getProfile returns a person with every skill they claim, highest level
+// first.
+func getProfile(ctx context.Context, db *sql.DB, id int64) (Profile, error) {
+	p, err := scanPerson(db.QueryRowContext(ctx, `SELECT `+personColumns+` FROM people WHERE id = $1`, id))
+	if err != nil {
+		return Profile{}, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+personSkillColumns+`
+		FROM person_skills ps JOIN skills s ON s.key = ps.skill_key
+		WHERE ps.person_id = $1 ORDER BY ps.level DESC, ps.skill_key`, id)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer rows.Close()
+
+	profile := Profile{Person: p, Skills: []PersonSkill{}}
+	for rows.Next() {
+		var ps PersonSkill
+		fields, done := personSkillFields(&ps)
+		if err := rows.Scan(fields...); err != nil {
+			return Profile{}, err
+		}
+		done()
+		profile.Skills = append(profile.Skills, ps)
+	}
+	return profile, rows.Err()
+}
+
+// listPeople returns everyone, ordered by name.
+func listPeople(ctx context.Context, db *sql.DB) ([]Person, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+personColumns+` FROM people ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	people := []Person{}
+	for rows.Next() {
+		p, err := scanPerson(rows)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, p)
+	}
+	return people, rows.Err()
+}
+
+// listPeopleWithSkill returns the people who claim a skill at minLevel or
+// above, highest level first, each with their claim.
+func listPeopleWithSkill(ctx context.Context, db *sql.DB, key string, minLevel int) ([]Profile, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+personColumns+`, `+personSkillColumns+`
+		FROM people p JOIN person_skills ps ON ps.person_id = p.id JOIN skills s ON s.key = ps.skill_key
+		WHERE ps.skill_key = $1 AND ps.level >= $2
+		ORDER BY ps.level DESC, p.name, p.id`, key, minLevel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []Profile{}
+	for rows.Next() {
+		var ps PersonSkill
+		fields, done := personSkillFields(&ps)
+		p, err := scanPerson(rows, fields...)
+		if err != nil {
+			return nil, err
+		}
+		done()
+		profiles = append(profiles, Profile{Person: p, Skills: []PersonSkill{ps}})
+	}
+	return profiles, rows.Err()
+}
+
+// putPersonSkill records that a person claims a skill, replacing what they
+// claimed about it before.
+func putPersonSkill(ctx context.Context, db *sql.DB, id int64, ps PersonSkill) (PersonSkill, error) {
+	fields, done := personSkillFields(&ps)
+	err := db.QueryRowContext(ctx, `WITH ps AS (
+			INSERT INTO person_skills (person_id, skill_key, level, last_used, interested, updated_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (person_id, skill_key) DO UPDATE
+			SET level = EXCLUDED.level, last_used = EXCLUDED.last_used, interested = EXCLUDED.interested,
+				updated_at = now(), updated_by = EXCLUDED.updated_by
+			RETURNING *
+		)
+		SELECT `+personSkillColumns+` FROM ps JOIN skills s ON s.key = ps.skill_key`,
+		id, ps.Key, ps.Level, ps.LastUsed, ps.Interested, skill.ActorFrom(ctx)).Scan(fields...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		if pqErr.Constraint == "person_skills_person_fkey" {
+			return PersonSkill{}, ErrPersonNotFound
+		}
+		return PersonSkill{}, skill.ErrSkillNotFound
+	} else if err != nil {
+		return PersonSkill{}, err
+	}
+	done()
+	return ps, nil
+}
+
+func deletePersonSkill(ctx context.Context, db *sql.DB, id int64, key string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM person_skills WHERE person_id = $1 AND skill_key = $2`, id, key)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrClaimNotFound
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package skill
+
+import (
+	"context"
+
+	"skillsapi/app/mtls"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnonymousActor is recorded as created_by or updated_by for writes made
+// without a client identity.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor returns a context whose writes are attributed to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor of ctx, or AnonymousActor when it has none.
+func ActorFrom(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// RequestContext is the context of a write handler, attributed to the client
+// identity of the request.
+func RequestContext(c *gin.Context) context.Context {
+	return WithActor(c.Request.Context(), c.GetString(mtls.IdentityKey))
+}
@@ -0,0 +1,121 @@
+package skill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrAliasTaken    = errors.New("alias is already a skill key or alias")
+	ErrAliasNotFound = errors.New("alias not found")
+)
+
+// Alias is another name a skill is known by.
+type Alias struct {
+	Alias     string    `json:"alias"`
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// aliasKey selects the key of the skill that has the name bound to the
+// placeholder p as an alias, if any.
+func aliasKey(p string) string {
+	return `SELECT key FROM skill_aliases WHERE lower(alias) = lower(` + p + `)`
+}
+
+// tagSynonyms selects the tag bound to the placeholder p together with every
+// name of the skill it names, if it names one: the key and the aliases.
+func tagSynonyms(p string) string {
+	canonical := `COALESCE((` + aliasKey(p) + `), ` + p + `)`
+	return `SELECT ` + p + `::text UNION SELECT ` + canonical +
+		` UNION SELECT alias FROM skill_aliases WHERE key = ` + canonical
+}
+
+// CanonicalKey returns the key of the skill named name, which is either its
+// key or one of its aliases.
+func (s *Storage) CanonicalKey(ctx context.Context, name string) (string, error) {
+	var key string
+	err := s.Db.QueryRowContext(ctx, `SELECT key FROM skills WHERE key = $1 UNION ALL `+aliasKey("$1")+` LIMIT 1`, name).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrSkillNotFound
+	}
+	return key, err
+}
+
+// ResolveSkill returns the skill named name, by key or by alias.
+func (s *Storage) ResolveSkill(ctx context.Context, name string) (Skill, error) {
+	skill, err := s.GetSkill(ctx, name)
+	if !errors.Is(err, ErrSkillNotFound) {
+		return skill, err
+	}
+	key, err := s.CanonicalKey(ctx, name)
+	if err != nil {
+		return Skill{}, err
+	}
+	return s.GetSkill(ctx, key)
+}
+
+// Aliases returns the aliases of a skill, ordered by alias.
+func (s *Storage) Aliases(ctx context.Context, key string) ([]Alias, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `SELECT alias, key, created_at, created_by FROM skill_aliases WHERE key = $1 ORDER BY alias`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := []Alias{}
+	for rows.Next() {
+		var a Alias
+		if err := rows.Scan(&a.Alias, &a.Key, &a.CreatedAt, &a.CreatedBy); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// CreateAlias gives a skill another name. The name must not already be a
+// skill key or an alias, compared case-insensitively.
+func (s *Storage) CreateAlias(ctx context.Context, key, alias string) (Alias, error) {
+	a := Alias{Alias: alias, Key: key}
+	err := s.Db.QueryRowContext(ctx, `INSERT INTO skill_aliases (alias, key, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING created_at, created_by`, alias, key, ActorFrom(ctx)).Scan(&a.CreatedAt, &a.CreatedBy)
+	if isForeignKeyViolation(err) {
+		return Alias{}, ErrSkillNotFound
+	} else if isUniqueViolation(err) {
+		return Alias{}, ErrAliasTaken
+	} else if err != nil {
+		return Alias{}, err
+	}
+	return a, nil
+}
+
+func (s *Storage) DeleteAlias(ctx context.Context, key, alias string) error {
+	result, err := s.Db.ExecContext(ctx, `DELETE FROM skill_aliases WHERE key = $1 AND lower(alias) = lower($2)`, key, alias)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrAliasNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,74 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSkillAliases(c *gin.Context) {
+	aliases, err := h.storage().Aliases(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, aliases, err)
+}
+
+func (h *Handler) CreateSkillAlias(c *gin.Context) {
+	var newAlias struct {
+		Alias string `json:"alias" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&newAlias); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	alias, err := h.storage().CreateAlias(RequestContext(c), c.Param("key"), newAlias.Alias)
+	if errors.Is(err, ErrSkillNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+		return
+	} else if errors.Is(err, ErrAliasTaken) {
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Alias is already a skill key or alias",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to create alias",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   alias,
+	})
+}
+
+func (h *Handler) DeleteSkillAlias(c *gin.Context) {
+	err := h.storage().DeleteAlias(c.Request.Context(), c.Param("key"), c.Param("alias"))
+	if errors.Is(err, ErrAliasNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Alias not found",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to delete alias",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Alias deleted",
+	})
+}
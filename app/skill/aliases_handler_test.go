@@ -0,0 +1,97 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliases(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	keys := func(w *httptest.ResponseRecorder) []string {
+		var response struct {
+			Data []Skill `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		keys := []string{}
+		for _, s := range response.Data {
+			keys = append(keys, s.Key)
+		}
+		return keys
+	}
+
+	t.Run("should create aliases", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/v1/skills/go/aliases", `{"alias":"golang"}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"alias":"golang","key":"go"`)
+
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/nodejs/aliases", `{"alias":"Node"}`).Code)
+
+		w = serve(http.MethodGet, "/api/v1/skills/go/aliases", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"alias":"golang"`)
+	})
+
+	t.Run("should keep names unique across keys and aliases", func(t *testing.T) {
+		for _, alias := range []string{"GoLang", "nodejs", "GO"} {
+			w := serve(http.MethodPost, "/api/v1/skills/go/aliases", `{"alias":"`+alias+`"}`)
+			assert.Equal(t, http.StatusConflict, w.Code, alias)
+			assert.JSONEq(t, `{"status":"error","message":"Alias is already a skill key or alias"}`, w.Body.String())
+		}
+
+		w := serve(http.MethodPost, "/api/v1/skills", `{"key":"golang","name":"Golang","description":"","logo":"","tags":[]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Skill already exists"}`, w.Body.String())
+
+		w = serve(http.MethodPost, "/api/v1/skills/rust/aliases", `{"alias":"rs"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should redirect an alias to its skill", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/golang?fields=key,name", "")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/v1/skills/go?fields=key,name", w.Header().Get("Location"))
+
+		w = serve(http.MethodGet, "/api/v1/skills/node", "")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/v1/skills/nodejs", w.Header().Get("Location"))
+
+		w = serve(http.MethodGet, "/api/v1/skills/rust", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should search and match tags by alias", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills?q=golan&fields=key", "")
+		assert.Equal(t, []string{"go"}, keys(w))
+
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"gin","name":"Gin","description":"","logo":"","tags":["golang"]}`).Code)
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"echo","name":"Echo","description":"","logo":"","tags":["go"]}`).Code)
+
+		w = serve(http.MethodGet, "/api/v1/skills?tag=go&fields=key", "")
+		assert.Equal(t, []string{"echo", "gin"}, keys(w))
+
+		w = serve(http.MethodGet, "/api/v1/skills?tag=golang&fields=key", "")
+		assert.Equal(t, []string{"echo", "gin"}, keys(w))
+	})
+
+	t.Run("should delete aliases", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/api/v1/skills/go/aliases/GOLANG", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve(http.MethodDelete, "/api/v1/skills/go/aliases/golang", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Alias not found"}`, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/skills/golang", "").Code)
+	})
+}
@@ -0,0 +1,29 @@
+package skill
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheControl lets clients and shared caches reuse a skill read for a
+// minute, after which they revalidate it with If-Modified-Since.
+const cacheControl = "public, max-age=60"
+
+// notModified sets the caching headers of a skill read and answers 304 Not
+// Modified when the client's copy, dated by If-Modified-Since, is current.
+func notModified(c *gin.Context, lastModified time.Time) bool {
+	c.Header("Cache-Control", cacheControl)
+	if lastModified.IsZero() {
+		return false
+	}
+	c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil || lastModified.Truncate(time.Second).After(since) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
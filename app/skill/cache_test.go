@@ -0,0 +1,51 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2026, 10, 19, 10, 0, 0, 500, time.UTC)
+
+	r := gin.New()
+	r.GET("/skill", func(c *gin.Context) {
+		if !notModified(c, lastModified) {
+			c.String(http.StatusOK, "go")
+		}
+	})
+
+	cases := []struct {
+		name            string
+		ifModifiedSince string
+		code            int
+	}{
+		{"without a date", "", http.StatusOK},
+		{"with the last modified date", "Mon, 19 Oct 2026 10:00:00 GMT", http.StatusNotModified},
+		{"with a later date", "Mon, 19 Oct 2026 11:00:00 GMT", http.StatusNotModified},
+		{"with an earlier date", "Mon, 19 Oct 2026 09:59:59 GMT", http.StatusOK},
+		{"with an invalid date", "yesterday", http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/skill", nil)
+			if tc.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tc.ifModifiedSince)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+			assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+			assert.Equal(t, "Mon, 19 Oct 2026 10:00:00 GMT", w.Header().Get("Last-Modified"))
+			if tc.code == http.StatusNotModified {
+				assert.Zero(t, w.Body.Len())
+			}
+		})
+	}
+}
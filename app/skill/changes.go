@@ -0,0 +1,64 @@
+package skill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"skillsapi/app/events"
+)
+
+// changesChannel is notified by a trigger on skills with a change for every
+// inserted, updated or deleted row.
+const changesChannel = "skill_changes"
+
+type change struct {
+	Op    string `json:"op"`
+	Key   string `json:"key"`
+	Actor string `json:"actor"`
+}
+
+// PublishChanges publishes every change to skills, whichever API instance or
+// SQL client made it, to broker until ctx is cancelled. Changes of a key are
+// published in the order they were committed.
+func PublishChanges(ctx context.Context, dsn string, storage *Storage, broker *events.Broker) error {
+	return events.Listen(ctx, dsn, changesChannel, func(payload string) {
+		if payload == "" {
+			slog.Warn("Skill changes made while reconnecting were not published")
+			return
+		}
+
+		var ch change
+		if err := json.Unmarshal([]byte(payload), &ch); err != nil {
+			slog.Error("Invalid skill change notification", "payload", payload, "error", err)
+			return
+		}
+		if e, ok := changeEvent(ctx, storage, ch); ok {
+			broker.Publish(e)
+		}
+	})
+}
+
+// changeEvent turns a notified change into an event. Created and updated
+// events carry the skill as it is now; a skill that has since been deleted
+// yields no event, as its deletion follows.
+func changeEvent(ctx context.Context, storage *Storage, ch change) (events.Event, bool) {
+	if ch.Op == "DELETE" {
+		return events.Event{Type: EventDeleted, Key: ch.Key, Data: DeletedSkill{Key: ch.Key, DeletedBy: ch.Actor}}, true
+	}
+
+	eventType := EventUpdated
+	if ch.Op == "INSERT" {
+		eventType = EventCreated
+	}
+
+	sk, err := storage.GetSkill(ctx, ch.Key)
+	if errors.Is(err, ErrSkillNotFound) {
+		return events.Event{}, false
+	} else if err != nil {
+		slog.Error("Failed to load changed skill", "key", ch.Key, "error", err)
+		return events.Event{}, false
+	}
+	return events.Event{Type: eventType, Key: ch.Key, Data: sk}, true
+}
@@ -0,0 +1,83 @@
+package skill
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"skillsapi/app/events"
+	"skillsapi/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeEventForDelete(t *testing.T) {
+	e, ok := changeEvent(context.Background(), nil, change{Op: "DELETE", Key: "go", Actor: "hr-portal"})
+
+	assert.True(t, ok)
+	assert.Equal(t, EventDeleted, e.Type)
+	assert.Equal(t, DeletedSkill{Key: "go", DeletedBy: "hr-portal"}, e.Data)
+}
+
+func TestPublishChanges(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := &Storage{Db: db}
+	broker := events.NewBroker(10)
+	go func() { _ = PublishChanges(ctx, os.Getenv("DATABASE_URL"), storage, broker) }()
+
+	_, ch, unsubscribe := broker.Subscribe(0)
+	defer unsubscribe()
+
+	// next skips the events of the probing updates to go below.
+	next := func() events.Event {
+		for {
+			select {
+			case e := <-ch:
+				if e.Key != "go" {
+					return e
+				}
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "no event published")
+				return events.Event{}
+			}
+		}
+	}
+
+	// The listener connects in the background; a direct write shows when it
+	// is ready, and changes made by other clients are published too.
+	require.Eventually(t, func() bool {
+		_, err := db.Exec(`UPDATE skills SET name = name WHERE key = 'go'`)
+		require.NoError(t, err)
+		select {
+		case e := <-ch:
+			return e.Type == EventUpdated && e.Key == "go"
+		case <-time.After(200 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+
+	actorCtx := WithActor(ctx, "hr-portal")
+	_, err := storage.CreateSkill(actorCtx, Skill{Key: "rust", Name: "Rust", Tags: []string{}})
+	require.NoError(t, err)
+	created := next()
+	assert.Equal(t, EventCreated, created.Type)
+	assert.Equal(t, "hr-portal", created.Data.(Skill).CreatedBy)
+
+	require.NoError(t, storage.DeleteSkill(actorCtx, "rust"))
+	assert.Equal(t, events.Event{ID: 0, Type: EventDeleted, Key: "rust", Data: DeletedSkill{Key: "rust", DeletedBy: "hr-portal"}},
+		withoutID(next()))
+}
+
+func withoutID(e events.Event) events.Event {
+	e.ID = 0
+	return e
+}
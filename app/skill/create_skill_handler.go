@@ -1,10 +1,10 @@
 package skill
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 func (h *Handler) CreateSkill(c *gin.Context) {
@@ -18,27 +18,20 @@ func (h *Handler) CreateSkill(c *gin.Context) {
 		return
 	}
 
-	var exists bool
-	err := h.Db.QueryRow(`SELECT EXISTS(SELECT 1 FROM skills WHERE key=$1)`, newSkill.Key).Scan(&exists)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	created, err := h.storage().CreateSkill(RequestContext(c), newSkill)
+	if errors.Is(err, ErrSkillAlreadyExists) {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Internal server error",
+			"message": "Skill already exists",
 		})
 		return
-	}
-
-	if exists {
+	} else if errors.Is(err, ErrParentNotFound) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Skill already exists",
+			"message": "Parent skill not found",
 		})
 		return
-	}
-
-	_, err = h.Db.Exec(`INSERT INTO skills (key, name, description, logo, tags) VALUES ($1, $2, $3, $4, $5)`,
-		newSkill.Key, newSkill.Name, newSkill.Description, newSkill.Logo, pq.Array(newSkill.Tags))
-	if err != nil {
+	} else if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
 			"message": "Internal server error",
@@ -48,6 +41,6 @@ func (h *Handler) CreateSkill(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   newSkill,
+		"data":   created,
 	})
 }
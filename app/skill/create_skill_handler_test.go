@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
 	"skillsapi/database"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +22,8 @@ func TestCreateSkill(t *testing.T) {
 	handler := Handler{Db: db}
 
 	router := gin.Default()
+	router.Use(func(c *gin.Context) { c.Set(mtls.IdentityKey, "hr-portal") })
+	router.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	router.POST("/api/v1/skills", handler.CreateSkill)
 
 	t.Run("should create a skill successfully", func(t *testing.T) {
@@ -46,6 +50,15 @@ func TestCreateSkill(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "hr-portal", data["created_by"])
+		assert.Equal(t, "hr-portal", data["updated_by"])
+		assert.NotEmpty(t, data["created_at"])
+		assert.Equal(t, data["created_at"], data["updated_at"])
+		for _, audit := range []string{"created_at", "created_by", "updated_at", "updated_by"} {
+			delete(data, audit)
+		}
+
 		expected := map[string]interface{}{
 			"status": "success",
 			"data": map[string]interface{}{
@@ -54,6 +67,7 @@ func TestCreateSkill(t *testing.T) {
 				"description": "Rust is a multi-paradigm system programming language.",
 				"logo":        "https://upload.wikimedia.org/wikipedia/commons/d/d5/Rust_programming_language_black_logo.svg",
 				"tags":        []interface{}{"rust", "systems programming"},
+				"parent_key":  nil,
 			},
 		}
 		assert.Equal(t, expected, response)
@@ -1,17 +1,84 @@
 package skill
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
 )
 
-func executeUpdate(c *gin.Context, db *sql.DB, query string, errorMessage string, args ...interface{}) {
-	key := c.Param("key")
+// Scanner is a *sql.Row or a *sql.Rows.
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryRower is a *sql.DB or a *sql.Tx.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func scanSkill(row Scanner) (Skill, error) {
+	return scanSkillFields(row, skillFields)
+}
+
+// scanSkillFields scans a row holding the given skill fields, in order, as
+// produced by selecting the columns of the same names, followed by any extra
+// columns into extra.
+func scanSkillFields(row Scanner, fields []string, extra ...interface{}) (Skill, error) {
+	var skill Skill
+	var tags pq.StringArray
+	dest := make([]interface{}, len(fields), len(fields)+len(extra))
+	for i, f := range fields {
+		switch f {
+		case "key":
+			dest[i] = &skill.Key
+		case "name":
+			dest[i] = &skill.Name
+		case "description":
+			dest[i] = &skill.Description
+		case "logo":
+			dest[i] = &skill.Logo
+		case "tags":
+			dest[i] = &tags
+		case "parent_key":
+			dest[i] = &skill.ParentKey
+		case "created_at":
+			dest[i] = &skill.CreatedAt
+		case "created_by":
+			dest[i] = &skill.CreatedBy
+		case "updated_at":
+			dest[i] = &skill.UpdatedAt
+		case "updated_by":
+			dest[i] = &skill.UpdatedBy
+		}
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Skill{}, ErrSkillNotFound
+	} else if err != nil {
+		return Skill{}, err
+	}
+	skill.Tags = []string(tags)
+	return skill, nil
+}
+
+// executeUpdate sets the given assignments, whose placeholders are numbered
+// from $1, on the skill with key and stamps it with the time and the actor of
+// ctx.
+func executeUpdate(ctx context.Context, db queryRower, key, set string, args ...interface{}) (Skill, error) {
+	n := len(args)
+	query := `UPDATE skills SET ` + set + `, updated_at = now(), updated_by = $` + strconv.Itoa(n+1) +
+		` WHERE key = $` + strconv.Itoa(n+2) + ` RETURNING ` + skillColumns
+	row := db.QueryRowContext(ctx, query, append(args, ActorFrom(ctx), key)...)
+	return scanSkill(row)
+}
 
-	_, err := db.Exec(query, args...)
+func respondUpdate(c *gin.Context, skill Skill, err error, errorMessage string) {
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -20,21 +87,31 @@ func executeUpdate(c *gin.Context, db *sql.DB, query string, errorMessage string
 		return
 	}
 
-	var skill Skill
-	var tags pq.StringArray
-	row := db.QueryRow(`SELECT key, name, description, logo, tags FROM skills WHERE key = $1`, key)
-	err = row.Scan(&skill.Key, &skill.Name, &skill.Description, &skill.Logo, &tags)
-	if err != nil {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   skill,
+	})
+}
+
+// respondSkillData answers with data read for a skill, or with a 404 when the
+// skill does not exist.
+func respondSkillData(c *gin.Context, data interface{}, err error) {
+	if errors.Is(err, ErrSkillNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+		return
+	} else if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
-			"message": errorMessage,
+			"message": "Internal server error",
 		})
 		return
 	}
-	skill.Tags = []string(tags)
 
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   skill,
+		"data":   data,
 	})
 }
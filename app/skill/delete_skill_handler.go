@@ -1,34 +1,27 @@
 package skill
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (h *Handler) DeleteSkill(c *gin.Context) {
-	key := c.Param("key")
-
-	var exists bool
-	err := h.Db.QueryRow(`SELECT EXISTS(SELECT 1 FROM skills WHERE key = $1)`, key).Scan(&exists)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	err := h.storage().DeleteSkill(RequestContext(c), c.Param("key"))
+	if errors.Is(err, ErrSkillNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
 			"status":  "error",
-			"message": "Internal server error",
+			"message": "Skill not found",
 		})
 		return
-	}
-
-	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{
+	} else if errors.Is(err, ErrSkillHasChildren) {
+		c.JSON(http.StatusConflict, gin.H{
 			"status":  "error",
-			"message": "Skill not found",
+			"message": "Skill has child skills",
 		})
 		return
-	}
-
-	_, err = h.Db.Exec(`DELETE FROM skills WHERE key = $1`, key)
-	if err != nil {
+	} else if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
 			"message": "not be able to delete skill",
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"skillsapi/app/openapi"
 	"skillsapi/database"
 	"testing"
 
@@ -19,6 +20,7 @@ func TestDeleteSkill(t *testing.T) {
 
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	r.POST("/api/v1/skills", h.CreateSkill)
 	r.DELETE("/api/v1/skills/:key", h.DeleteSkill)
 
@@ -0,0 +1,41 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EventSource is the CloudEvents source of skill events.
+const EventSource = "/api/v1/skills"
+
+// EventSchemaVersion versions the data of skill events: Skill for created
+// and updated events, DeletedSkill for deleted ones. Adding a field keeps the
+// version. Removing or renaming a field, or changing its type or meaning,
+// breaks consumers and needs a new version; TestEventSchema fails until the
+// schema it records is updated with it.
+const EventSchemaVersion = 1
+
+// EventDataSchema is the CloudEvents dataschema of an event type.
+func EventDataSchema(eventType string) string {
+	return fmt.Sprintf("urn:skillsapi:schema:%s:v%d", eventType, EventSchemaVersion)
+}
+
+// EventData decodes the recorded data of an event and encodes it again
+// through Skill or DeletedSkill, so that events only carry the fields of the
+// current schema in their documented format.
+func EventData(eventType string, raw json.RawMessage) (json.RawMessage, error) {
+	var data interface{}
+	switch eventType {
+	case EventCreated, EventUpdated:
+		data = &Skill{}
+	case EventDeleted:
+		data = &DeletedSkill{}
+	default:
+		return nil, fmt.Errorf("unknown skill event type %q", eventType)
+	}
+
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
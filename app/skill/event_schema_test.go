@@ -0,0 +1,78 @@
+package skill
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventSchemas records the JSON fields and Go types of each version of the
+// skill event data.
+var eventSchemas = map[int]map[string]map[string]string{
+	1: {
+		"Skill": {
+			"key":         "string",
+			"name":        "string",
+			"description": "string",
+			"logo":        "string",
+			"tags":        "[]string",
+			"parent_key":  "*string",
+			"created_at":  "time.Time",
+			"created_by":  "string",
+			"updated_at":  "time.Time",
+			"updated_by":  "string",
+		},
+		"DeletedSkill": {
+			"key":        "string",
+			"deleted_by": "string",
+		},
+	},
+}
+
+func jsonFields(v interface{}) map[string]string {
+	fields := map[string]string{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type.String()
+		}
+	}
+	return fields
+}
+
+func TestEventSchema(t *testing.T) {
+	schema, ok := eventSchemas[EventSchemaVersion]
+	require.True(t, ok, "record the fields of event schema version %d in eventSchemas", EventSchemaVersion)
+
+	current := map[string]map[string]string{
+		"Skill":        jsonFields(Skill{}),
+		"DeletedSkill": jsonFields(DeletedSkill{}),
+	}
+	for typ, fields := range schema {
+		for name, kind := range fields {
+			assert.Equal(t, kind, current[typ][name],
+				"%s.%s was removed or changed: bump EventSchemaVersion and record the new schema", typ, name)
+		}
+	}
+}
+
+func TestEventData(t *testing.T) {
+	data, err := EventData(EventUpdated, []byte(`{"key":"go","name":"Go","description":"","logo":"","tags":["system"],"parent_key":"backend",
+		"created_at":"2024-01-01T00:00:00+00:00","created_by":"seed","updated_at":"2024-01-02T10:00:00.5+02:00","updated_by":"hr-portal","internal":1}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"go","name":"Go","description":"","logo":"","tags":["system"],"parent_key":"backend",
+		"created_at":"2024-01-01T00:00:00Z","created_by":"seed","updated_at":"2024-01-02T10:00:00.5+02:00","updated_by":"hr-portal"}`, string(data))
+
+	data, err = EventData(EventDeleted, []byte(`{"key":"go","deleted_by":"hr-portal"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"go","deleted_by":"hr-portal"}`, string(data))
+
+	_, err = EventData("skill.renamed", []byte(`{}`))
+	assert.Error(t, err)
+
+	assert.Equal(t, "urn:skillsapi:schema:skill.created:v1", EventDataSchema(EventCreated))
+}
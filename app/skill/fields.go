@@ -0,0 +1,134 @@
+package skill
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var skillFields = []string{"key", "name", "description", "logo", "tags", "parent_key", "created_at", "created_by", "updated_at", "updated_by"}
+
+var sortableFields = []string{"key", "name", "created_at", "updated_at"}
+
+var errInvalidQuery = errors.New("invalid query parameter")
+
+// parseListOptions reads the filtering, paging, sparse fieldset and sorting
+// query parameters of GET /api/v1/skills.
+func parseListOptions(c *gin.Context) (ListOptions, string, error) {
+	opts := ListOptions{
+		Tag:    c.Query("tag"),
+		Search: c.Query("q"),
+		Under:  c.Query("under"),
+	}
+
+	if since := c.Query("updated_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return ListOptions{}, "Invalid updated_since parameter", errInvalidQuery
+		}
+		opts.UpdatedSince = t
+	}
+
+	fields, err := parseFields(c)
+	if err != nil {
+		return ListOptions{}, "Invalid fields parameter", err
+	}
+	opts.Fields = fields
+
+	if sort := c.Query("sort"); sort != "" {
+		for _, name := range strings.Split(sort, ",") {
+			sf := SortField{Field: strings.TrimSpace(name)}
+			if strings.HasPrefix(sf.Field, "-") {
+				sf.Field, sf.Desc = sf.Field[1:], true
+			}
+			if !slices.Contains(sortableFields, sf.Field) {
+				return ListOptions{}, "Invalid sort parameter", errInvalidQuery
+			}
+			opts.Sort = append(opts.Sort, sf)
+		}
+	}
+
+	if opts.Limit, err = parseNonNegative(c, "limit"); err != nil {
+		return ListOptions{}, "Invalid limit parameter", err
+	}
+	if opts.Offset, err = parseNonNegative(c, "offset"); err != nil {
+		return ListOptions{}, "Invalid offset parameter", err
+	}
+	return opts, "", nil
+}
+
+func parseFields(c *gin.Context) ([]string, error) {
+	param := c.Query("fields")
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(skillFields, name) {
+			return nil, errInvalidQuery
+		}
+		if !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+func parseNonNegative(c *gin.Context, name string) (int, error) {
+	param := c.Query(name)
+	if param == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return 0, errInvalidQuery
+	}
+	return n, nil
+}
+
+// project keeps only the requested fields of a skill. A nil fields list keeps
+// the whole skill.
+func project(skill Skill, fields []string) interface{} {
+	if fields == nil {
+		return skill
+	}
+
+	projected := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		projected[f] = fieldValue(skill, f)
+	}
+	return projected
+}
+
+func fieldValue(skill Skill, field string) interface{} {
+	switch field {
+	case "key":
+		return skill.Key
+	case "name":
+		return skill.Name
+	case "description":
+		return skill.Description
+	case "logo":
+		return skill.Logo
+	case "tags":
+		return skill.Tags
+	case "parent_key":
+		return skill.ParentKey
+	case "created_at":
+		return skill.CreatedAt
+	case "created_by":
+		return skill.CreatedBy
+	case "updated_at":
+		return skill.UpdatedAt
+	case "updated_by":
+		return skill.UpdatedBy
+	}
+	return nil
+}
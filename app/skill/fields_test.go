@@ -0,0 +1,70 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListOptions(t *testing.T) {
+	parse := func(rawQuery string) (ListOptions, string, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/skills?"+rawQuery, nil)
+		return parseListOptions(c)
+	}
+
+	t.Run("should parse fields, sort, filters and paging", func(t *testing.T) {
+		opts, _, err := parse("fields=key,name,logo,key&sort=name,-key&tag=runtime&q=node&under=backend&updated_since=2026-10-01T00:00:00Z&limit=10&offset=20")
+		assert.NoError(t, err)
+		assert.Equal(t, ListOptions{
+			Tag:          "runtime",
+			Search:       "node",
+			Under:        "backend",
+			UpdatedSince: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			Fields:       []string{"key", "name", "logo"},
+			Sort:         []SortField{{Field: "name"}, {Field: "key", Desc: true}},
+			Limit:        10,
+			Offset:       20,
+		}, opts)
+	})
+
+	tests := map[string]string{
+		"fields=key,password": "Invalid fields parameter",
+		"sort=description":    "Invalid sort parameter",
+		"sort=-":              "Invalid sort parameter",
+		"limit=-1":            "Invalid limit parameter",
+		"offset=ten":          "Invalid offset parameter",
+		"updated_since=today": "Invalid updated_since parameter",
+	}
+	for query, message := range tests {
+		t.Run("should reject "+query, func(t *testing.T) {
+			_, got, err := parse(query)
+			assert.Error(t, err)
+			assert.Equal(t, message, got)
+		})
+	}
+}
+
+func TestProjectedRendering(t *testing.T) {
+	sk := Skill{Key: "go", Name: "Go", Description: "Go, the language", Logo: "go.svg", Tags: []string{"a", "b"}}
+	r := gin.New()
+	r.GET("/skill", func(c *gin.Context) {
+		if format, ok := negotiateFormat(c); ok {
+			renderSkill(c, format, sk, []string{"key", "name", "tags"})
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/skill", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.JSONEq(t, `{"status":"success","data":{"key":"go","name":"Go","tags":["a","b"]}}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/skill?format=csv", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "key,name,tags\ngo,Go,a|b\n", w.Body.String())
+}
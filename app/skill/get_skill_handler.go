@@ -1,15 +1,42 @@
 package skill
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 func (h *Handler) GetSkills(c *gin.Context) {
-	rows, err := h.Db.Query(`SELECT key, name, description, logo, tags FROM skills`)
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
+
+	opts, message, err := parseListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": message,
+		})
+		return
+	}
+
+	locales, ok := requestedLocales(c)
+	if !ok {
+		return
+	}
+	// Translations are looked up by key, so read it even when it is not
+	// returned.
+	query := opts
+	if len(opts.Fields) > 0 && !slices.Contains(opts.Fields, "key") {
+		query.Fields = append([]string{"key"}, opts.Fields...)
+	}
+
+	lastModified, err := h.storage().LastModified(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -17,47 +44,56 @@ func (h *Handler) GetSkills(c *gin.Context) {
 		})
 		return
 	}
-	defer rows.Close()
-
-	var skills []Skill
-	for rows.Next() {
-		var skill Skill
-		var tags pq.StringArray
-		err := rows.Scan(&skill.Key, &skill.Name, &skill.Description, &skill.Logo, &tags)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"message": "Internal server error",
-			})
-			return
-		}
-		skill.Tags = []string(tags)
-		skills = append(skills, skill)
+	if notModified(c, lastModified) {
+		return
 	}
 
-	if rows.Err() != nil {
+	skills, err := h.storage().ListSkills(c.Request.Context(), query)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
 			"message": "Internal server error",
 		})
 		return
 	}
+	if !h.localize(c, skills, locales) {
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"data":   skills,
-	})
+	renderSkills(c, format, skills, opts.Fields)
 }
 
+// GetSkill returns a skill by key. A request naming the skill by one of its
+// aliases is redirected to the skill's key.
 func (h *Handler) GetSkill(c *gin.Context) {
-	key := c.Param("key")
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
 
-	row := h.Db.QueryRow(`SELECT key, name, description, logo, tags FROM skills WHERE key = $1`, key)
+	fields, err := parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid fields parameter",
+		})
+		return
+	}
+
+	locales, ok := requestedLocales(c)
+	if !ok {
+		return
+	}
 
-	var skill Skill
-	var tags pq.StringArray
-	err := row.Scan(&skill.Key, &skill.Name, &skill.Description, &skill.Logo, &tags)
-	if err == sql.ErrNoRows {
+	skill, err := h.storage().GetSkill(c.Request.Context(), c.Param("key"))
+	if errors.Is(err, ErrSkillNotFound) {
+		var key string
+		if key, err = h.storage().CanonicalKey(c.Request.Context(), c.Param("key")); err == nil {
+			redirectToSkill(c, key)
+			return
+		}
+	}
+	if errors.Is(err, ErrSkillNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{
 			"status":  "error",
 			"message": "Skill not found",
@@ -70,10 +106,43 @@ func (h *Handler) GetSkill(c *gin.Context) {
 		})
 		return
 	}
-	skill.Tags = []string(tags)
 
-	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"data":   skill,
-	})
+	skills := []Skill{skill}
+	if !h.localize(c, skills, locales) {
+		return
+	}
+	skill = skills[0]
+
+	if notModified(c, skill.UpdatedAt) {
+		return
+	}
+	renderSkill(c, format, skill, fields)
+}
+
+// redirectToSkill redirects permanently to the skill with key, keeping the
+// query string. The redirect is cached like a skill read, since aliases can
+// be removed.
+func redirectToSkill(c *gin.Context, key string) {
+	c.Header("Cache-Control", cacheControl)
+	location := url.URL{Path: "/api/v1/skills/" + key, RawQuery: c.Request.URL.RawQuery}
+	c.Redirect(http.StatusMovedPermanently, location.String())
+}
+
+// followAlias redirects a request for a skill named by an alias or former key
+// to the same path under its key, with 308 so that the method and body are
+// sent again.
+func (h *Handler) followAlias(c *gin.Context) {
+	name := c.Param("key")
+	key, err := h.storage().CanonicalKey(c.Request.Context(), name)
+	if err != nil || key == name {
+		return
+	}
+
+	prefix := "/api/v1/skills/"
+	location := url.URL{
+		Path:     prefix + key + strings.TrimPrefix(c.Request.URL.Path, prefix+name),
+		RawQuery: c.Request.URL.RawQuery,
+	}
+	c.Redirect(http.StatusPermanentRedirect, location.String())
+	c.Abort()
 }
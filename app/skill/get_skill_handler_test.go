@@ -3,8 +3,10 @@ package skill
 import (
 	"net/http"
 	"net/http/httptest"
+	"skillsapi/app/openapi"
 	"skillsapi/database"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,7 @@ func TestGetAllSkills(t *testing.T) {
 
 	handler := Handler{Db: db}
 	router := gin.Default()
+	router.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	router.GET("/api/v1/skills", handler.GetSkills)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/skills", nil)
@@ -33,14 +36,24 @@ func TestGetAllSkills(t *testing.T) {
 				"name": "Go",
 				"description": "Go is a statically typed, compiled programming language designed at Google.",
 				"logo": "https://upload.wikimedia.org/wikipedia/commons/0/05/Go_Logo_Blue.svg",
-				"tags": ["programming language", "system"]
+				"tags": ["programming language", "system"],
+				"parent_key": null,
+				"created_at": "2024-01-01T00:00:00Z",
+				"created_by": "seed",
+				"updated_at": "2024-01-01T00:00:00Z",
+				"updated_by": "seed"
 			},
 			{
 				"key": "nodejs",
 				"name": "Node.js",
 				"description": "Node.js is an open-source, cross-platform, JavaScript runtime environment that executes JavaScript code outside of a browser.",
 				"logo": "https://upload.wikimedia.org/wikipedia/commons/d/d9/Node.js_logo.svg",
-				"tags": ["runtime", "javascript"]
+				"tags": ["runtime", "javascript"],
+				"parent_key": null,
+				"created_at": "2024-01-01T00:00:00Z",
+				"created_by": "seed",
+				"updated_at": "2024-01-01T00:00:00Z",
+				"updated_by": "seed"
 			}
 		]
 	}`
@@ -55,6 +68,7 @@ func TestGetSkillById(t *testing.T) {
 
 	handler := Handler{Db: db}
 	router := gin.Default()
+	router.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	router.GET("/api/v1/skills/:key", handler.GetSkill)
 
 	t.Run("should return a skill successfully", func(t *testing.T) {
@@ -70,7 +84,12 @@ func TestGetSkillById(t *testing.T) {
 				"name": "Go",
 				"description": "Go is a statically typed, compiled programming language designed at Google.",
 				"logo": "https://upload.wikimedia.org/wikipedia/commons/0/05/Go_Logo_Blue.svg",
-				"tags": ["programming language", "system"]
+				"tags": ["programming language", "system"],
+				"parent_key": null,
+				"created_at": "2024-01-01T00:00:00Z",
+				"created_by": "seed",
+				"updated_at": "2024-01-01T00:00:00Z",
+				"updated_by": "seed"
 			}
 		}`
 		assert.JSONEq(t, expected, recorder.Body.String())
@@ -86,3 +105,45 @@ func TestGetSkillById(t *testing.T) {
 		assert.JSONEq(t, expected, recorder.Body.String())
 	})
 }
+
+func TestGetSkillsIfModifiedSince(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	handler := Handler{Db: db}
+	router := gin.Default()
+	router.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	router.GET("/api/v1/skills", handler.GetSkills)
+	router.GET("/api/v1/skills/:key", handler.GetSkill)
+
+	get := func(path, ifModifiedSince string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	for _, path := range []string{"/api/v1/skills", "/api/v1/skills/go"} {
+		first := get(path, "")
+		assert.Equal(t, http.StatusOK, first.Code, path)
+		lastModified := first.Header().Get("Last-Modified")
+		assert.NotEmpty(t, lastModified, path)
+
+		again := get(path, lastModified)
+		assert.Equal(t, http.StatusNotModified, again.Code, path)
+		assert.Empty(t, again.Body.String(), path)
+	}
+
+	t.Run("should see a deleted skill as a change to the list", func(t *testing.T) {
+		since := time.Now().Add(-time.Second).UTC().Format(http.TimeFormat)
+		_, err := db.Exec(`DELETE FROM skills WHERE key = 'nodejs'`)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, get("/api/v1/skills", since).Code)
+	})
+}
@@ -0,0 +1,43 @@
+package skill
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testIdentityHeader names the client identity of a test request, which
+// mutual TLS would otherwise establish.
+const testIdentityHeader = "X-Test-Identity"
+
+// serveFunc sends a JSON request, with the given header name and value pairs,
+// to the router a test is set up with.
+type serveFunc func(method, url, body string, header ...string) *httptest.ResponseRecorder
+
+// newTestServer routes requests to h, checking responses against the OpenAPI
+// document.
+func newTestServer(h *Handler) serveFunc {
+	r := gin.New()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	r.Use(func(c *gin.Context) {
+		if identity := c.GetHeader(testIdentityHeader); identity != "" {
+			c.Set(mtls.IdentityKey, identity)
+		}
+	})
+	SetRouter(r, h)
+
+	return func(method, url, body string, header ...string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+}
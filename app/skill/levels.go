@@ -0,0 +1,128 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// MaxLevels is the most levels a rubric can have.
+const MaxLevels = 10
+
+var (
+	ErrTooManyLevels      = errors.New("too many levels")
+	ErrLevelOrder         = errors.New("levels must be numbered from 1 in order")
+	ErrDuplicateLevelName = errors.New("duplicate level name")
+)
+
+// Level is a step of the proficiency ladder of a skill, such as
+// "3 - Proficient".
+type Level struct {
+	Level       int    `json:"level"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Rubric is the proficiency ladder that applies to a skill. InheritedFrom is
+// the key of the ancestor the levels come from, or nil when the skill defines
+// them or no skill above it does.
+type Rubric struct {
+	Key           string  `json:"key"`
+	InheritedFrom *string `json:"inherited_from"`
+	Levels        []Level `json:"levels"`
+}
+
+// validateLevels checks that levels are numbered 1, 2, 3 and so on in order,
+// and that no two of them share a name, ignoring case.
+func validateLevels(levels []Level) error {
+	if len(levels) > MaxLevels {
+		return ErrTooManyLevels
+	}
+	names := map[string]bool{}
+	for i, level := range levels {
+		if level.Level != i+1 {
+			return ErrLevelOrder
+		}
+		name := strings.ToLower(level.Name)
+		if names[name] {
+			return ErrDuplicateLevelName
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// Levels returns the rubric of a skill: its own levels, or else those of its
+// nearest ancestor that has any.
+func (s *Storage) Levels(ctx context.Context, key string) (Rubric, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return Rubric{}, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `WITH RECURSIVE ancestors AS (
+			SELECT key, parent_key, 0 AS depth FROM skills WHERE key = $1
+			UNION ALL
+			SELECT p.key, p.parent_key, a.depth + 1 FROM skills p JOIN ancestors a ON p.key = a.parent_key
+		) CYCLE key SET is_cycle USING path
+		SELECT a.key, l.level, l.name, l.description FROM ancestors a JOIN skill_levels l ON l.key = a.key
+		WHERE NOT a.is_cycle ORDER BY a.depth, l.level`, key)
+	if err != nil {
+		return Rubric{}, err
+	}
+	defer rows.Close()
+
+	rubric := Rubric{Key: key, Levels: []Level{}}
+	var source string
+	for rows.Next() {
+		var from string
+		var level Level
+		if err := rows.Scan(&from, &level.Level, &level.Name, &level.Description); err != nil {
+			return Rubric{}, err
+		}
+		if source != "" && from != source {
+			// The levels of ancestors further up do not apply.
+			break
+		}
+		source = from
+		rubric.Levels = append(rubric.Levels, level)
+	}
+	if err := rows.Err(); err != nil {
+		return Rubric{}, err
+	}
+	if source != "" && source != key {
+		rubric.InheritedFrom = &source
+	}
+	return rubric, nil
+}
+
+// PutLevels replaces the levels of a skill. No levels removes the skill's own
+// rubric, so that it inherits one again. The skill is stamped as updated.
+func (s *Storage) PutLevels(ctx context.Context, key string, levels []Level) (Rubric, error) {
+	if err := validateLevels(levels); err != nil {
+		return Rubric{}, err
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Rubric{}, err
+	}
+	defer tx.Rollback()
+
+	if err := touchSkill(ctx, tx, key); err != nil {
+		return Rubric{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM skill_levels WHERE key = $1`, key); err != nil {
+		return Rubric{}, err
+	}
+	for _, level := range levels {
+		_, err := tx.ExecContext(ctx, `INSERT INTO skill_levels (key, level, name, description) VALUES ($1, $2, $3, $4)`,
+			key, level.Level, level.Name, level.Description)
+		if err != nil {
+			return Rubric{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return Rubric{}, err
+	}
+	return s.Levels(ctx, key)
+}
@@ -0,0 +1,70 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSkillLevels(c *gin.Context) {
+	rubric, err := h.storage().Levels(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, rubric, err)
+}
+
+// PutSkillLevels replaces the proficiency ladder of a skill. An empty list
+// makes the skill inherit the ladder of its category again.
+func (h *Handler) PutSkillLevels(c *gin.Context) {
+	var rubric struct {
+		Levels []struct {
+			Level       int    `json:"level" binding:"required"`
+			Name        string `json:"name" binding:"required"`
+			Description string `json:"description"`
+		} `json:"levels" binding:"required,dive"`
+	}
+	if err := c.ShouldBindJSON(&rubric); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	levels := make([]Level, len(rubric.Levels))
+	for i, level := range rubric.Levels {
+		levels[i] = Level{Level: level.Level, Name: level.Name, Description: level.Description}
+	}
+	saved, err := h.storage().PutLevels(RequestContext(c), c.Param("key"), levels)
+	switch {
+	case errors.Is(err, ErrSkillNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+	case errors.Is(err, ErrTooManyLevels):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "A skill can have at most 10 levels",
+		})
+	case errors.Is(err, ErrLevelOrder):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Levels must be numbered from 1 in order",
+		})
+	case errors.Is(err, ErrDuplicateLevelName):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Level names must be unique",
+		})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to save levels",
+		})
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   saved,
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	rubric := func(w *httptest.ResponseRecorder) Rubric {
+		var response struct {
+			Data Rubric `json:"data"`
+		}
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"backend","name":"Backend","description":"","logo":"","tags":[]}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPatch, "/api/v1/skills/go/actions/parent", `{"parent_key":"backend"}`).Code)
+
+	t.Run("should have no levels until a rubric is defined", func(t *testing.T) {
+		got := rubric(serve(http.MethodGet, "/api/v1/skills/go/levels", ""))
+		assert.Equal(t, Rubric{Key: "go", Levels: []Level{}}, got)
+	})
+
+	t.Run("should inherit the levels of the category", func(t *testing.T) {
+		got := rubric(serve(http.MethodPut, "/api/v1/skills/backend/levels",
+			`{"levels":[{"level":1,"name":"Novice"},{"level":2,"name":"Proficient","description":"Works unaided"}]}`))
+		assert.Nil(t, got.InheritedFrom)
+		assert.Len(t, got.Levels, 2)
+
+		got = rubric(serve(http.MethodGet, "/api/v1/skills/go/levels", ""))
+		require.NotNil(t, got.InheritedFrom)
+		assert.Equal(t, "backend", *got.InheritedFrom)
+		assert.Equal(t, []Level{{Level: 1, Name: "Novice"}, {Level: 2, Name: "Proficient", Description: "Works unaided"}}, got.Levels)
+	})
+
+	t.Run("should prefer the skill's own levels", func(t *testing.T) {
+		rubric(serve(http.MethodPut, "/api/v1/skills/go/levels", `{"levels":[{"level":1,"name":"Writes Go"}]}`))
+
+		got := rubric(serve(http.MethodGet, "/api/v1/skills/go/levels", ""))
+		assert.Nil(t, got.InheritedFrom)
+		assert.Equal(t, []Level{{Level: 1, Name: "Writes Go"}}, got.Levels)
+
+		got = rubric(serve(http.MethodPut, "/api/v1/skills/go/levels", `{"levels":[]}`))
+		require.NotNil(t, got.InheritedFrom)
+		assert.Equal(t, "backend", *got.InheritedFrom)
+	})
+
+	t.Run("should validate the levels", func(t *testing.T) {
+		for _, body := range []string{
+			`{}`,
+			`{"levels":[{"level":1}]}`,
+			`{"levels":[{"level":2,"name":"Proficient"}]}`,
+			`{"levels":[{"level":1,"name":"Novice"},{"level":2,"name":"NOVICE"}]}`,
+		} {
+			assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/api/v1/skills/go/levels", body).Code, body)
+		}
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/skills/nope/levels", `{"levels":[]}`).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/skills/nope/levels", "").Code)
+	})
+}
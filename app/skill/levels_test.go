@@ -0,0 +1,40 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLevels(t *testing.T) {
+	ladder := func(names ...string) []Level {
+		levels := make([]Level, len(names))
+		for i, name := range names {
+			levels[i] = Level{Level: i + 1, Name: name}
+		}
+		return levels
+	}
+
+	t.Run("should accept a ladder numbered from 1", func(t *testing.T) {
+		assert.NoError(t, validateLevels(ladder("Novice", "Competent", "Proficient")))
+		assert.NoError(t, validateLevels(nil))
+	})
+
+	t.Run("should reject gaps and disorder", func(t *testing.T) {
+		assert.ErrorIs(t, validateLevels([]Level{{Level: 2, Name: "Competent"}}), ErrLevelOrder)
+		assert.ErrorIs(t, validateLevels([]Level{{Level: 1, Name: "Novice"}, {Level: 3, Name: "Expert"}}), ErrLevelOrder)
+		assert.ErrorIs(t, validateLevels([]Level{{Level: 2, Name: "Competent"}, {Level: 1, Name: "Novice"}}), ErrLevelOrder)
+	})
+
+	t.Run("should reject names used twice", func(t *testing.T) {
+		assert.ErrorIs(t, validateLevels(ladder("Novice", "novice")), ErrDuplicateLevelName)
+	})
+
+	t.Run("should reject long ladders", func(t *testing.T) {
+		names := make([]string, MaxLevels+1)
+		for i := range names {
+			names[i] = string(rune('a' + i))
+		}
+		assert.ErrorIs(t, validateLevels(ladder(names...)), ErrTooManyLevels)
+	})
+}
@@ -0,0 +1,107 @@
+package skill
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale of the names and descriptions stored on skills
+// when Handler.DefaultLocale is not set.
+const DefaultLocale = "en"
+
+func (h *Handler) defaultLocale() string {
+	if locale, ok := parseLocale(h.DefaultLocale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// parseLocale returns the canonical form of a BCP 47 tag, such as "th" or
+// "en-US".
+func parseLocale(s string) (string, bool) {
+	tag, err := language.Parse(s)
+	if err != nil || tag == language.Und {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// localeChain returns the locales a request asks for, most wanted first:
+// the lang query parameter, or else the Accept-Language header by quality.
+// Each locale is followed by the more general ones it falls back to, such as
+// th-TH by th. A malformed Accept-Language header is ignored; a malformed
+// lang parameter is not.
+func localeChain(c *gin.Context) ([]string, bool) {
+	var tags []language.Tag
+	if lang := c.Query("lang"); lang != "" {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, false
+		}
+		tags = []language.Tag{tag}
+	} else if header := c.GetHeader("Accept-Language"); header != "" {
+		accepted, q, err := language.ParseAcceptLanguage(header)
+		if err == nil {
+			for i, tag := range accepted {
+				if q[i] > 0 {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	var chain []string
+	for _, tag := range tags {
+		for ; tag != language.Und; tag = tag.Parent() {
+			if locale := tag.String(); !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+		}
+	}
+	return chain, true
+}
+
+// requestedLocales returns the locale chain of a request. It answers 400
+// itself and returns false when the lang parameter is malformed.
+func requestedLocales(c *gin.Context) ([]string, bool) {
+	chain, ok := localeChain(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid lang parameter",
+		})
+		return nil, false
+	}
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	return chain, true
+}
+
+// localize translates skills along chain and says which locales were served
+// in Content-Language. It answers 500 itself and returns false when the
+// translations cannot be read.
+func (h *Handler) localize(c *gin.Context, skills []Skill, chain []string) bool {
+	served, err := h.storage().Localize(c.Request.Context(), skills, chain, h.defaultLocale())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Internal server error",
+		})
+		return false
+	}
+
+	var locales []string
+	for _, locale := range served {
+		if !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	if len(locales) == 0 {
+		locales = []string{h.defaultLocale()}
+	}
+	c.Header("Content-Language", strings.Join(locales, ", "))
+	return true
+}
@@ -0,0 +1,48 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleChain(t *testing.T) {
+	chain := func(url, acceptLanguage string) ([]string, bool) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest(http.MethodGet, url, nil)
+		if acceptLanguage != "" {
+			c.Request.Header.Set("Accept-Language", acceptLanguage)
+		}
+		return localeChain(c)
+	}
+
+	t.Run("should follow Accept-Language by quality with fallbacks", func(t *testing.T) {
+		locales, ok := chain("/", "en;q=0.5, th-TH, fr;q=0")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"th-TH", "th", "en"}, locales)
+	})
+
+	t.Run("should prefer the lang parameter", func(t *testing.T) {
+		locales, ok := chain("/?lang=TH", "en")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"th"}, locales)
+	})
+
+	t.Run("should ask for nothing without preferences", func(t *testing.T) {
+		locales, ok := chain("/", "")
+		assert.True(t, ok)
+		assert.Empty(t, locales)
+
+		locales, ok = chain("/", "not a locale;;")
+		assert.True(t, ok)
+		assert.Empty(t, locales)
+	})
+
+	t.Run("should reject a malformed lang parameter", func(t *testing.T) {
+		_, ok := chain("/?lang=not-a-locale!", "")
+		assert.False(t, ok)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"skillsapi/app/openapi"
 	"skillsapi/database"
 	"testing"
 
@@ -29,8 +30,9 @@ func TestPatchSkillName(t *testing.T) {
 
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	r.POST("/api/v1/skills", h.CreateSkill)
-	r.PUT("/api/v1/skills/:key/action/name", h.UpdateSkillName)
+	r.PATCH("/api/v1/skills/:key/actions/name", h.UpdateSkillName)
 
 	skill := Skill{
 		Key:         "testUpdateName",
@@ -49,7 +51,7 @@ func TestPatchSkillName(t *testing.T) {
 			Name: "updateName",
 		}
 		jsonValue, _ := json.Marshal(name)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/name", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/name", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -62,7 +64,7 @@ func TestPatchSkillName(t *testing.T) {
 			Name: "updateName",
 		}
 		jsonValue, _ := json.Marshal(name)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/name", "unexistedkey"), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/name", "unexistedkey"), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -75,7 +77,7 @@ func TestPatchSkillName(t *testing.T) {
 			Name: "",
 		}
 		jsonValue, _ := json.Marshal(name)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/name", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/name", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -88,8 +90,9 @@ func TestPatchSkillDescription(t *testing.T) {
 
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	r.POST("/api/v1/skills", h.CreateSkill)
-	r.PUT("/api/v1/skills/:key/action/description", h.UpdateSkillDescription)
+	r.PATCH("/api/v1/skills/:key/actions/description", h.UpdateSkillDescription)
 
 	skill := Skill{
 		Key:         "testUpdateDescription",
@@ -108,7 +111,7 @@ func TestPatchSkillDescription(t *testing.T) {
 			Desc: "updateDescription",
 		}
 		jsonValue, _ := json.Marshal(desc)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/description", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/description", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -121,7 +124,7 @@ func TestPatchSkillDescription(t *testing.T) {
 			Desc: "updateDescription",
 		}
 		jsonValue, _ := json.Marshal(desc)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/description", "unexistedkey"), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/description", "unexistedkey"), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -134,7 +137,7 @@ func TestPatchSkillDescription(t *testing.T) {
 			Desc: "",
 		}
 		jsonValue, _ := json.Marshal(desc)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/description", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/description", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -147,8 +150,9 @@ func TestPatchSkillLogo(t *testing.T) {
 
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	r.POST("/api/v1/skills", h.CreateSkill)
-	r.PUT("/api/v1/skills/:key/action/logo", h.UpdateSkillLogo)
+	r.PATCH("/api/v1/skills/:key/actions/logo", h.UpdateSkillLogo)
 
 	skill := Skill{
 		Key:         "testUpdateLogo",
@@ -167,7 +171,7 @@ func TestPatchSkillLogo(t *testing.T) {
 			Logo: "updateLogo",
 		}
 		jsonValue, _ := json.Marshal(logo)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/logo", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/logo", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -180,7 +184,7 @@ func TestPatchSkillLogo(t *testing.T) {
 			Logo: "updateLogo",
 		}
 		jsonValue, _ := json.Marshal(logo)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/logo", "unexistedkey"), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/logo", "unexistedkey"), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -193,7 +197,7 @@ func TestPatchSkillLogo(t *testing.T) {
 			Logo: "",
 		}
 		jsonValue, _ := json.Marshal(logo)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/logo", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/logo", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -206,8 +210,9 @@ func TestPatchSkillTags(t *testing.T) {
 
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 	r.POST("/api/v1/skills", h.CreateSkill)
-	r.PUT("/api/v1/skills/:key/action/tags", h.UpdateSkillTags)
+	r.PATCH("/api/v1/skills/:key/actions/tags", h.UpdateSkillTags)
 
 	skill := Skill{
 		Key:         "testUpdateTags",
@@ -226,7 +231,7 @@ func TestPatchSkillTags(t *testing.T) {
 			Tags: []string{"update"},
 		}
 		jsonValue, _ := json.Marshal(tags)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/tags", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/tags", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -239,7 +244,7 @@ func TestPatchSkillTags(t *testing.T) {
 			Tags: []string{"update"},
 		}
 		jsonValue, _ := json.Marshal(tags)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/tags", "unexistedkey"), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/tags", "unexistedkey"), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -252,7 +257,7 @@ func TestPatchSkillTags(t *testing.T) {
 			Tags: nil,
 		}
 		jsonValue, _ := json.Marshal(tags)
-		req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/skills/%v/action/tags", skill.Key), bytes.NewBuffer(jsonValue))
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/api/v1/skills/%v/actions/tags", skill.Key), bytes.NewBuffer(jsonValue))
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusBadRequest, w.Code)
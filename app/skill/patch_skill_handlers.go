@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 func (h *Handler) UpdateSkillName(c *gin.Context) {
@@ -19,8 +18,8 @@ func (h *Handler) UpdateSkillName(c *gin.Context) {
 		return
 	}
 
-	query := `UPDATE skills SET name = $1 WHERE key = $2`
-	executeUpdate(c, h.Db, query, "not be able to update skill name", updateName.Name, c.Param("key"))
+	skill, err := h.storage().PatchSkill(RequestContext(c), c.Param("key"), SkillPatch{Name: &updateName.Name})
+	respondUpdate(c, skill, err, "not be able to update skill name")
 }
 
 func (h *Handler) UpdateSkillDescription(c *gin.Context) {
@@ -35,8 +34,8 @@ func (h *Handler) UpdateSkillDescription(c *gin.Context) {
 		return
 	}
 
-	query := `UPDATE skills SET description = $1 WHERE key = $2`
-	executeUpdate(c, h.Db, query, "not be able to update skill description", updateDescription.Description, c.Param("key"))
+	skill, err := h.storage().PatchSkill(RequestContext(c), c.Param("key"), SkillPatch{Description: &updateDescription.Description})
+	respondUpdate(c, skill, err, "not be able to update skill description")
 }
 
 func (h *Handler) UpdateSkillLogo(c *gin.Context) {
@@ -51,8 +50,8 @@ func (h *Handler) UpdateSkillLogo(c *gin.Context) {
 		return
 	}
 
-	query := `UPDATE skills SET logo = $1 WHERE key = $2`
-	executeUpdate(c, h.Db, query, "not be able to update skill logo", updateLogo.Logo, c.Param("key"))
+	skill, err := h.storage().PatchSkill(RequestContext(c), c.Param("key"), SkillPatch{Logo: &updateLogo.Logo})
+	respondUpdate(c, skill, err, "not be able to update skill logo")
 }
 
 func (h *Handler) UpdateSkillTags(c *gin.Context) {
@@ -67,6 +66,6 @@ func (h *Handler) UpdateSkillTags(c *gin.Context) {
 		return
 	}
 
-	query := `UPDATE skills SET tags = $1 WHERE key = $2`
-	executeUpdate(c, h.Db, query, "not be able to update skill tags", pq.Array(updateTags.Tags), c.Param("key"))
+	skill, err := h.storage().PatchSkill(RequestContext(c), c.Param("key"), SkillPatch{Tags: updateTags.Tags})
+	respondUpdate(c, skill, err, "not be able to update skill tags")
 }
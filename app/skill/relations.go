@@ -0,0 +1,206 @@
+package skill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	// RelationRequires is a directed edge: the from skill builds on the to
+	// skill. Requires edges never form a cycle.
+	RelationRequires = "requires"
+	// RelationRelated is a symmetric edge between two skills.
+	RelationRelated = "related"
+)
+
+var (
+	ErrInvalidRelationType = errors.New("invalid relation type")
+	ErrSelfRelation        = errors.New("skill cannot be related to itself")
+	ErrRelatedNotFound     = errors.New("related skill not found")
+	ErrRelationExists      = errors.New("relation already exists")
+	ErrRelationCycle       = errors.New("relation would create a prerequisite cycle")
+	ErrRelationNotFound    = errors.New("relation not found")
+)
+
+// Relation is a typed edge between two skills. Related edges are returned
+// from the point of view of the skill they were listed for.
+type Relation struct {
+	Type      string    `json:"type"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// Prerequisite is a skill required, directly or not, by another skill. Depth
+// is the length of the shortest chain of requires edges leading to it.
+type Prerequisite struct {
+	Skill
+	Depth int `json:"depth"`
+}
+
+func ValidRelationType(t string) bool {
+	return t == RelationRequires || t == RelationRelated
+}
+
+// storedEdge returns the ends of an edge as stored: related edges keep the
+// smaller key first, compared byte by byte like the "C" collation of the
+// table's check.
+func storedEdge(from, typ, to string) (string, string) {
+	if typ == RelationRelated && to < from {
+		return to, from
+	}
+	return from, to
+}
+
+// Relations returns the edges touching a skill, of the given type or of any
+// type when typ is empty, ordered by type and keys.
+func (s *Storage) Relations(ctx context.Context, key, typ string) ([]Relation, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `SELECT type, from_key, to_key, created_at, created_by FROM (
+			SELECT type,
+				CASE WHEN type = 'related' AND to_key = $1 THEN to_key ELSE from_key END AS from_key,
+				CASE WHEN type = 'related' AND to_key = $1 THEN from_key ELSE to_key END AS to_key,
+				created_at, created_by
+			FROM skill_relations WHERE (from_key = $1 OR to_key = $1) AND ($2 = '' OR type = $2)
+		) r ORDER BY type, from_key, to_key`, key, typ)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relations := []Relation{}
+	for rows.Next() {
+		var r Relation
+		if err := rows.Scan(&r.Type, &r.From, &r.To, &r.CreatedAt, &r.CreatedBy); err != nil {
+			return nil, err
+		}
+		relations = append(relations, r)
+	}
+	return relations, rows.Err()
+}
+
+// CreateRelation adds an edge of type typ from one skill to another. New
+// requires edges are serialised for the same reason as SetParent's moves.
+func (s *Storage) CreateRelation(ctx context.Context, from, typ, to string) (Relation, error) {
+	if !ValidRelationType(typ) {
+		return Relation{}, ErrInvalidRelationType
+	}
+	if from == to {
+		return Relation{}, ErrSelfRelation
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Relation{}, err
+	}
+	defer tx.Rollback()
+
+	if typ == RelationRequires {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('skill_relations'))`); err != nil {
+			return Relation{}, err
+		}
+	}
+
+	var fromExists, toExists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM skills WHERE key = $1), EXISTS (SELECT 1 FROM skills WHERE key = $2)`,
+		from, to).Scan(&fromExists, &toExists)
+	if err != nil {
+		return Relation{}, err
+	}
+	if !fromExists {
+		return Relation{}, ErrSkillNotFound
+	}
+	if !toExists {
+		return Relation{}, ErrRelatedNotFound
+	}
+
+	if typ == RelationRequires {
+		// The edge closes a cycle when the skill is already a prerequisite of
+		// the one it would require.
+		var cycle bool
+		err := tx.QueryRowContext(ctx, `WITH RECURSIVE reachable AS (
+				SELECT to_key AS key FROM skill_relations WHERE from_key = $1 AND type = 'requires'
+				UNION
+				SELECT r.to_key FROM skill_relations r JOIN reachable ON r.from_key = reachable.key WHERE r.type = 'requires'
+			)
+			SELECT EXISTS (SELECT 1 FROM reachable WHERE key = $2)`, to, from).Scan(&cycle)
+		if err != nil {
+			return Relation{}, err
+		}
+		if cycle {
+			return Relation{}, ErrRelationCycle
+		}
+	}
+
+	stored0, stored1 := storedEdge(from, typ, to)
+	r := Relation{Type: typ, From: from, To: to}
+	err = tx.QueryRowContext(ctx, `INSERT INTO skill_relations (from_key, to_key, type, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING created_at, created_by`, stored0, stored1, typ, ActorFrom(ctx)).Scan(&r.CreatedAt, &r.CreatedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Relation{}, ErrRelationExists
+	} else if isForeignKeyViolation(err) {
+		return Relation{}, ErrRelatedNotFound
+	} else if err != nil {
+		return Relation{}, err
+	}
+	return r, tx.Commit()
+}
+
+// DeleteRelation removes an edge of type typ from one skill to another.
+func (s *Storage) DeleteRelation(ctx context.Context, from, typ, to string) error {
+	from, to = storedEdge(from, typ, to)
+	result, err := s.Db.ExecContext(ctx, `DELETE FROM skill_relations WHERE from_key = $1 AND type = $2 AND to_key = $3`, from, typ, to)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRelationNotFound
+	}
+	return nil
+}
+
+// Prerequisites returns every skill a skill requires, directly or through
+// other skills, nearest first.
+func (s *Storage) Prerequisites(ctx context.Context, key string) ([]Prerequisite, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `WITH RECURSIVE prerequisites AS (
+			SELECT to_key AS key, 1 AS depth FROM skill_relations WHERE from_key = $1 AND type = 'requires'
+			UNION ALL
+			SELECT r.to_key, p.depth + 1 FROM skill_relations r JOIN prerequisites p ON r.from_key = p.key WHERE r.type = 'requires'
+		) CYCLE key SET is_cycle USING path
+		SELECT `+qualifiedColumns("s")+`, min(p.depth) AS depth
+		FROM prerequisites p JOIN skills s ON s.key = p.key
+		WHERE NOT p.is_cycle
+		GROUP BY s.key
+		ORDER BY depth, s.key`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prerequisites := []Prerequisite{}
+	for rows.Next() {
+		var p Prerequisite
+		p.Skill, err = scanSkillFields(rows, skillFields, &p.Depth)
+		if err != nil {
+			return nil, err
+		}
+		prerequisites = append(prerequisites, p)
+	}
+	return prerequisites, rows.Err()
+}
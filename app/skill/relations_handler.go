@@ -0,0 +1,111 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSkillRelations(c *gin.Context) {
+	typ := c.Query("type")
+	if typ != "" && !ValidRelationType(typ) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid type parameter",
+		})
+		return
+	}
+
+	relations, err := h.storage().Relations(c.Request.Context(), c.Param("key"), typ)
+	respondSkillData(c, relations, err)
+}
+
+// CreateSkillRelation adds a requires or related edge from the skill to the
+// skill named in the body.
+func (h *Handler) CreateSkillRelation(c *gin.Context) {
+	var newRelation struct {
+		Type string `json:"type" binding:"required"`
+		Key  string `json:"key" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&newRelation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	relation, err := h.storage().CreateRelation(RequestContext(c), c.Param("key"), newRelation.Type, newRelation.Key)
+	switch {
+	case errors.Is(err, ErrSkillNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+	case errors.Is(err, ErrInvalidRelationType):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid relation type",
+		})
+	case errors.Is(err, ErrSelfRelation):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Skill cannot be related to itself",
+		})
+	case errors.Is(err, ErrRelatedNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Related skill not found",
+		})
+	case errors.Is(err, ErrRelationExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Relation already exists",
+		})
+	case errors.Is(err, ErrRelationCycle):
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Relation would create a prerequisite cycle",
+		})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to create relation",
+		})
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   relation,
+		})
+	}
+}
+
+func (h *Handler) DeleteSkillRelation(c *gin.Context) {
+	err := h.storage().DeleteRelation(c.Request.Context(), c.Param("key"), c.Param("type"), c.Param("related_key"))
+	if errors.Is(err, ErrRelationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Relation not found",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to delete relation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Relation deleted",
+	})
+}
+
+// GetSkillPrerequisites returns everything a skill requires, transitively,
+// nearest first.
+func (h *Handler) GetSkillPrerequisites(c *gin.Context) {
+	prerequisites, err := h.storage().Prerequisites(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, prerequisites, err)
+}
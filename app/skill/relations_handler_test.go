@@ -0,0 +1,120 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelations(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	relate := func(from, typ, to string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/skills/"+from+"/relations", `{"type":"`+typ+`","key":"`+to+`"}`)
+	}
+
+	for _, key := range []string{"docker", "kubernetes", "linux"} {
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"`+key+`","name":"`+key+`","description":"","logo":"","tags":[]}`).Code)
+	}
+
+	// kubernetes > docker > linux, with go required by kubernetes directly.
+	t.Run("should create relations", func(t *testing.T) {
+		w := relate("kubernetes", "requires", "docker")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"type":"requires","from":"kubernetes","to":"docker"`)
+
+		require.Equal(t, http.StatusOK, relate("docker", "requires", "linux").Code)
+		require.Equal(t, http.StatusOK, relate("kubernetes", "requires", "go").Code)
+		require.Equal(t, http.StatusOK, relate("nodejs", "related", "go").Code)
+	})
+
+	t.Run("should reject invalid relations", func(t *testing.T) {
+		w := relate("linux", "requires", "kubernetes")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Relation would create a prerequisite cycle"}`, w.Body.String())
+
+		w = relate("go", "related", "nodejs")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Relation already exists"}`, w.Body.String())
+
+		w = relate("go", "requires", "go")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Skill cannot be related to itself"}`, w.Body.String())
+
+		w = relate("go", "requires", "rust")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Related skill not found"}`, w.Body.String())
+
+		w = relate("go", "extends", "nodejs")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Invalid relation type"}`, w.Body.String())
+
+		w = relate("rust", "requires", "go")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should list relations from both ends", func(t *testing.T) {
+		var response struct {
+			Data []Relation `json:"data"`
+		}
+		w := serve(http.MethodGet, "/api/v1/skills/go/relations", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, Relation{Type: "related", From: "go", To: "nodejs", CreatedAt: response.Data[0].CreatedAt, CreatedBy: "anonymous"}, response.Data[0])
+		assert.Equal(t, "kubernetes", response.Data[1].From)
+
+		w = serve(http.MethodGet, "/api/v1/skills/go/relations?type=requires", "")
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+
+		w = serve(http.MethodGet, "/api/v1/skills/go/relations?type=extends", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should list prerequisites transitively", func(t *testing.T) {
+		var response struct {
+			Data []Prerequisite `json:"data"`
+		}
+		w := serve(http.MethodGet, "/api/v1/skills/kubernetes/prerequisites", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		depths := map[string]int{}
+		for _, p := range response.Data {
+			depths[p.Key] = p.Depth
+		}
+		assert.Equal(t, map[string]int{"docker": 1, "go": 1, "linux": 2}, depths)
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/skills/rust/prerequisites", "").Code)
+	})
+
+	t.Run("should delete relations", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/api/v1/skills/nodejs/relations/related/go", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve(http.MethodDelete, "/api/v1/skills/nodejs/relations/related/go", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Relation not found"}`, w.Body.String())
+	})
+
+	t.Run("should drop the relations of a deleted skill", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(http.MethodDelete, "/api/v1/skills/docker", "").Code)
+
+		w := serve(http.MethodGet, "/api/v1/skills/kubernetes/relations", "")
+		var response struct {
+			Data []Relation `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "go", response.Data[0].To)
+	})
+}
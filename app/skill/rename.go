@@ -0,0 +1,91 @@
+package skill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// ErrCaseOnlyRename is returned for a rename that only changes the case of a
+// key. The old key could not be kept as an alias, since aliases match
+// ignoring case, and requests for it would no longer find the skill.
+var ErrCaseOnlyRename = errors.New("rename only changes the case of the key")
+
+// RenameSkill changes the key of a skill. Rows referring to the skill follow
+// through ON UPDATE CASCADE, and the old key becomes an alias, so that it keeps
+// resolving to the skill. A skill can be renamed to one of its own aliases,
+// but not to the key or alias of another skill.
+//
+// Related edges are the exception: the new key may sort on the other side of
+// the related skill, so they are taken out before the rename and stored again
+// in order afterwards.
+func (s *Storage) RenameSkill(ctx context.Context, key, newKey string) (Skill, error) {
+	if strings.EqualFold(newKey, key) {
+		if _, err := s.GetSkill(ctx, key); err != nil {
+			return Skill{}, err
+		}
+		if newKey == key {
+			return Skill{}, ErrSkillAlreadyExists
+		}
+		return Skill{}, ErrCaseOnlyRename
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Skill{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM skill_aliases WHERE key = $1 AND lower(alias) = lower($2)`, key, newKey); err != nil {
+		return Skill{}, err
+	}
+
+	related, err := takeRelated(ctx, tx, key)
+	if err != nil {
+		return Skill{}, err
+	}
+
+	skill, err := executeUpdate(ctx, tx, key, `key = $1`, newKey)
+	if isUniqueViolation(err) {
+		return Skill{}, ErrSkillAlreadyExists
+	} else if err != nil {
+		return Skill{}, err
+	}
+
+	for _, r := range related {
+		from, to := storedEdge(newKey, RelationRelated, r.To)
+		_, err := tx.ExecContext(ctx, `INSERT INTO skill_relations (from_key, to_key, type, created_at, created_by)
+			VALUES ($1, $2, $3, $4, $5)`, from, to, RelationRelated, r.CreatedAt, r.CreatedBy)
+		if err != nil {
+			return Skill{}, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO skill_aliases (alias, key, created_by) VALUES ($1, $2, $3)`, key, newKey, ActorFrom(ctx))
+	if err != nil {
+		return Skill{}, err
+	}
+	return skill, tx.Commit()
+}
+
+// takeRelated deletes the related edges of a skill and returns them from its
+// point of view.
+func takeRelated(ctx context.Context, tx *sql.Tx, key string) ([]Relation, error) {
+	rows, err := tx.QueryContext(ctx, `DELETE FROM skill_relations WHERE type = 'related' AND (from_key = $1 OR to_key = $1)
+		RETURNING CASE WHEN from_key = $1 THEN to_key ELSE from_key END, created_at, created_by`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var related []Relation
+	for rows.Next() {
+		r := Relation{Type: RelationRelated, From: key}
+		if err := rows.Scan(&r.To, &r.CreatedAt, &r.CreatedBy); err != nil {
+			return nil, err
+		}
+		related = append(related, r)
+	}
+	return related, rows.Err()
+}
@@ -0,0 +1,55 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RenameSkill moves a skill to a new key. Requests for the old key keep
+// being redirected to it.
+func (h *Handler) RenameSkill(c *gin.Context) {
+	var rename struct {
+		Key string `json:"key" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&rename); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	skill, err := h.storage().RenameSkill(RequestContext(c), c.Param("key"), rename.Key)
+	if errors.Is(err, ErrSkillNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+		return
+	} else if errors.Is(err, ErrCaseOnlyRename) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Key must differ by more than case",
+		})
+		return
+	} else if errors.Is(err, ErrSkillAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Key is already a skill key or alias",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to rename skill",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   skill,
+	})
+}
@@ -0,0 +1,104 @@
+package skill
+
+import (
+	"net/http"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameSkill(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"gin","name":"Gin","description":"","logo":"","tags":[],"parent_key":"go"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/nodejs/relations", `{"type":"related","key":"go"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/go/aliases", `{"alias":"golang"}`).Code)
+
+	t.Run("should rename a skill to one of its aliases", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/v1/skills/go/actions/rename", `{"key":"golang"}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"key":"golang"`)
+
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/skills/golang", "").Code)
+	})
+
+	t.Run("should publish the rename as a deletion and a creation", func(t *testing.T) {
+		rows, err := db.Query(`SELECT event_type, aggregate_key FROM outbox ORDER BY id DESC LIMIT 2`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var published []string
+		for rows.Next() {
+			var eventType, key string
+			require.NoError(t, rows.Scan(&eventType, &key))
+			published = append(published, eventType+" "+key)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []string{"skill.created golang", "skill.deleted go"}, published)
+	})
+
+	t.Run("should redirect the old key", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/go", "")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/v1/skills/golang", w.Header().Get("Location"))
+
+		w = serve(http.MethodGet, "/api/v1/skills/golang/aliases", "")
+		assert.Contains(t, w.Body.String(), `"alias":"go","key":"golang"`)
+		assert.NotContains(t, w.Body.String(), `"alias":"golang"`)
+
+		w = serve(http.MethodPatch, "/api/v1/skills/go/actions/name", `{"name":"Go"}`)
+		assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+		assert.Equal(t, "/api/v1/skills/golang/actions/name", w.Header().Get("Location"))
+
+		w = serve(http.MethodDelete, "/api/v1/skills/go/aliases/go", "")
+		assert.Equal(t, http.StatusPermanentRedirect, w.Code)
+		assert.Equal(t, "/api/v1/skills/golang/aliases/go", w.Header().Get("Location"))
+	})
+
+	t.Run("should move referencing rows along", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/gin", "")
+		assert.Contains(t, w.Body.String(), `"parent_key":"golang"`)
+
+		w = serve(http.MethodGet, "/api/v1/skills/nodejs/relations", "")
+		assert.Contains(t, w.Body.String(), `"from":"nodejs","to":"golang"`)
+	})
+
+	t.Run("should keep related edges in order when the key moves past the other skill", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/v1/skills/golang/actions/rename", `{"key":"zzz"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = serve(http.MethodGet, "/api/v1/skills/nodejs/relations", "")
+		assert.Contains(t, w.Body.String(), `"from":"nodejs","to":"zzz"`)
+
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/zzz/actions/rename", `{"key":"golang"}`).Code)
+		w = serve(http.MethodGet, "/api/v1/skills/nodejs/relations", "")
+		assert.Contains(t, w.Body.String(), `"from":"nodejs","to":"golang"`)
+	})
+
+	t.Run("should not take a name in use", func(t *testing.T) {
+		for _, key := range []string{"go", "gin", "nodejs"} {
+			w := serve(http.MethodPost, "/api/v1/skills/nodejs/actions/rename", `{"key":"`+key+`"}`)
+			assert.Equal(t, http.StatusConflict, w.Code, key)
+			assert.JSONEq(t, `{"status":"error","message":"Key is already a skill key or alias"}`, w.Body.String())
+		}
+
+		w := serve(http.MethodPost, "/api/v1/skills/rust/actions/rename", `{"key":"rustlang"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serve(http.MethodPost, "/api/v1/skills/nodejs/actions/rename", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should not only change the case", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/v1/skills/nodejs/actions/rename", `{"key":"NodeJS"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Key must differ by more than case"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,194 @@
+package skill
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatYAML   = "yaml"
+	formatNDJSON = "ndjson"
+
+	defaultTagsDelimiter = "|"
+)
+
+var contentTypes = map[string]string{
+	formatJSON:   "application/json; charset=utf-8",
+	formatCSV:    "text/csv; charset=utf-8",
+	formatYAML:   "application/yaml; charset=utf-8",
+	formatNDJSON: "application/x-ndjson",
+}
+
+var mediaTypes = map[string]string{
+	"application/json":     formatJSON,
+	"text/csv":             formatCSV,
+	"application/yaml":     formatYAML,
+	"application/x-yaml":   formatYAML,
+	"text/yaml":            formatYAML,
+	"application/x-ndjson": formatNDJSON,
+	"application/ndjson":   formatNDJSON,
+	"application/*":        formatJSON,
+	"text/*":               formatCSV,
+	"*/*":                  formatJSON,
+}
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// negotiateFormat picks the representation for a skill read from the
+// ?format= override or, failing that, the Accept header. It writes a 406 and
+// returns false when none of the supported formats is acceptable.
+func negotiateFormat(c *gin.Context) (string, bool) {
+	c.Writer.Header().Add("Vary", "Accept")
+
+	if f := c.Query("format"); f != "" {
+		if _, ok := contentTypes[f]; ok {
+			return f, true
+		}
+		notAcceptable(c)
+		return "", false
+	}
+
+	accept := c.GetHeader("Accept")
+	if accept == "" {
+		return formatJSON, true
+	}
+
+	for _, r := range parseAccept(accept) {
+		if f, ok := mediaTypes[r.mediaType]; ok {
+			return f, true
+		}
+	}
+	notAcceptable(c)
+	return "", false
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		r := mediaRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), q: 1}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					r.q = q
+				}
+			}
+		}
+		if r.mediaType != "" && r.q > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+	return ranges
+}
+
+func notAcceptable(c *gin.Context) {
+	c.JSON(http.StatusNotAcceptable, gin.H{
+		"status":  "error",
+		"message": "Not acceptable, supported formats are json, csv, yaml and ndjson",
+	})
+}
+
+func renderSkills(c *gin.Context, format string, skills []Skill, fields []string) {
+	switch format {
+	case formatCSV:
+		renderCSV(c, skills, fields)
+	case formatNDJSON:
+		renderNDJSON(c, skills, fields)
+	default:
+		data := make([]interface{}, 0, len(skills))
+		for _, skill := range skills {
+			data = append(data, project(skill, fields))
+		}
+		renderEnvelope(c, format, data)
+	}
+}
+
+func renderSkill(c *gin.Context, format string, skill Skill, fields []string) {
+	switch format {
+	case formatCSV:
+		renderCSV(c, []Skill{skill}, fields)
+	case formatNDJSON:
+		renderNDJSON(c, []Skill{skill}, fields)
+	default:
+		renderEnvelope(c, format, project(skill, fields))
+	}
+}
+
+func renderEnvelope(c *gin.Context, format string, data interface{}) {
+	body := gin.H{
+		"status": "success",
+		"data":   data,
+	}
+	if format == formatYAML {
+		out, err := yaml.Marshal(body)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Internal server error",
+			})
+			return
+		}
+		c.Data(http.StatusOK, contentTypes[formatYAML], out)
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+func renderCSV(c *gin.Context, skills []Skill, fields []string) {
+	delimiter := c.DefaultQuery("tags_delimiter", defaultTagsDelimiter)
+	if fields == nil {
+		fields = skillFields
+	}
+
+	c.Header("Content-Type", contentTypes[formatCSV])
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(fields)
+	for _, skill := range skills {
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			switch v := fieldValue(skill, f).(type) {
+			case []string:
+				record[i] = strings.Join(v, delimiter)
+			case time.Time:
+				record[i] = v.Format(time.RFC3339Nano)
+			case *string:
+				if v != nil {
+					record[i] = *v
+				}
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+}
+
+func renderNDJSON(c *gin.Context, skills []Skill, fields []string) {
+	c.Header("Content-Type", contentTypes[formatNDJSON])
+	c.Status(http.StatusOK)
+	enc := json.NewEncoder(c.Writer)
+	for _, skill := range skills {
+		_ = enc.Encode(project(skill, fields))
+	}
+}
@@ -0,0 +1,132 @@
+package skill
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRenderRouter() *gin.Engine {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	skills := []Skill{
+		{Key: "go", Name: "Go", Description: "Go, the language", Logo: "go.svg", Tags: []string{"programming language", "system"},
+			CreatedAt: at, CreatedBy: "seed", UpdatedAt: at, UpdatedBy: "hr-portal"},
+		{Key: "nodejs", Name: "Node.js", Description: "JavaScript runtime", Logo: "node.svg", Tags: []string{"runtime"},
+			CreatedAt: at, CreatedBy: "seed", UpdatedAt: at, UpdatedBy: "seed"},
+	}
+
+	r := gin.New()
+	r.GET("/skills", func(c *gin.Context) {
+		if format, ok := negotiateFormat(c); ok {
+			renderSkills(c, format, skills, nil)
+		}
+	})
+	r.GET("/skill", func(c *gin.Context) {
+		if format, ok := negotiateFormat(c); ok {
+			renderSkill(c, format, skills[0], nil)
+		}
+	})
+	return r
+}
+
+func TestContentNegotiation(t *testing.T) {
+	r := newRenderRouter()
+
+	tests := []struct {
+		name        string
+		url         string
+		accept      string
+		code        int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "should default to JSON",
+			url:         "/skill",
+			code:        http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"data":{"key":"go","name":"Go","description":"Go, the language","logo":"go.svg","tags":["programming language","system"],"parent_key":null,"created_at":"2026-01-02T03:04:05Z","created_by":"seed","updated_at":"2026-01-02T03:04:05Z","updated_by":"hr-portal"},"status":"success"}`,
+		},
+		{
+			name:        "should render CSV with tags joined by the default delimiter",
+			url:         "/skills",
+			accept:      "text/csv",
+			code:        http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			body:        "key,name,description,logo,tags,parent_key,created_at,created_by,updated_at,updated_by\ngo,Go,\"Go, the language\",go.svg,programming language|system,,2026-01-02T03:04:05Z,seed,2026-01-02T03:04:05Z,hr-portal\nnodejs,Node.js,JavaScript runtime,node.svg,runtime,,2026-01-02T03:04:05Z,seed,2026-01-02T03:04:05Z,seed\n",
+		},
+		{
+			name:        "should join CSV tags with a custom delimiter",
+			url:         "/skill?format=csv&tags_delimiter=%3B",
+			code:        http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			body:        "key,name,description,logo,tags,parent_key,created_at,created_by,updated_at,updated_by\ngo,Go,\"Go, the language\",go.svg,programming language;system,,2026-01-02T03:04:05Z,seed,2026-01-02T03:04:05Z,hr-portal\n",
+		},
+		{
+			name:        "should render NDJSON one skill per line",
+			url:         "/skills",
+			accept:      "application/x-ndjson",
+			code:        http.StatusOK,
+			contentType: "application/x-ndjson",
+			body: `{"key":"go","name":"Go","description":"Go, the language","logo":"go.svg","tags":["programming language","system"],"parent_key":null,"created_at":"2026-01-02T03:04:05Z","created_by":"seed","updated_at":"2026-01-02T03:04:05Z","updated_by":"hr-portal"}` + "\n" +
+				`{"key":"nodejs","name":"Node.js","description":"JavaScript runtime","logo":"node.svg","tags":["runtime"],"parent_key":null,"created_at":"2026-01-02T03:04:05Z","created_by":"seed","updated_at":"2026-01-02T03:04:05Z","updated_by":"seed"}` + "\n",
+		},
+		{
+			name:        "should render YAML",
+			url:         "/skill",
+			accept:      "application/yaml",
+			code:        http.StatusOK,
+			contentType: "application/yaml; charset=utf-8",
+			body:        "data:\n    key: go\n    name: Go\n    description: Go, the language\n    logo: go.svg\n    tags:\n        - programming language\n        - system\n    parent_key: null\n    created_at: 2026-01-02T03:04:05Z\n    created_by: seed\n    updated_at: 2026-01-02T03:04:05Z\n    updated_by: hr-portal\nstatus: success\n",
+		},
+		{
+			name:        "should pick the format with the highest quality",
+			url:         "/skill",
+			accept:      "application/json;q=0.5, application/x-ndjson",
+			code:        http.StatusOK,
+			contentType: "application/x-ndjson",
+		},
+		{
+			name:        "should let the format parameter override the Accept header",
+			url:         "/skill?format=yaml",
+			accept:      "application/json",
+			code:        http.StatusOK,
+			contentType: "application/yaml; charset=utf-8",
+		},
+		{
+			name:   "should reject an unsupported Accept header",
+			url:    "/skill",
+			accept: "application/xml",
+			code:   http.StatusNotAcceptable,
+		},
+		{
+			name: "should reject an unsupported format parameter",
+			url:  "/skills?format=xml",
+			code: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,18 +1,50 @@
 package skill
 
 import (
+	"time"
+
+	"skillsapi/app/idempotency"
+	"skillsapi/app/mtls"
+
 	"github.com/gin-gonic/gin"
 )
 
+const idempotencyTTL = 24 * time.Hour
+
 func SetRouter(r *gin.Engine, h *Handler) {
+	admin := mtls.RequireIdentity(h.Admins...)
+
 	r.GET("/ping", GetPing)
 	r.GET("/api/v1/skills", h.GetSkills)
+	r.GET("/api/v1/skills/events", h.GetSkillEvents)
+	r.GET("/api/v1/skills/suggest", h.GetSkillSuggestions)
 	r.GET("/api/v1/skills/:key", h.GetSkill)
-	r.POST("/api/v1/skills", h.CreateSkill)
-	r.PUT("/api/v1/skills/:key", h.UpdateSkill)
-	r.PATCH("/api/v1/skills/:key/actions/name", h.UpdateSkillName)
-	r.PATCH("/api/v1/skills/:key/actions/description", h.UpdateSkillDescription)
-	r.PATCH("/api/v1/skills/:key/actions/logo", h.UpdateSkillLogo)
-	r.PATCH("/api/v1/skills/:key/actions/tags", h.UpdateSkillTags)
-	r.DELETE("/api/v1/skills/:key", h.DeleteSkill)
+	r.GET("/api/v1/skills/:key/children", h.followAlias, h.GetSkillChildren)
+	r.GET("/api/v1/skills/:key/ancestors", h.followAlias, h.GetSkillAncestors)
+	r.GET("/api/v1/skills/:key/subtree", h.followAlias, h.GetSkillSubtree)
+	r.GET("/api/v1/skills/:key/relations", h.followAlias, h.GetSkillRelations)
+	r.GET("/api/v1/skills/:key/prerequisites", h.followAlias, h.GetSkillPrerequisites)
+	r.GET("/api/v1/skills/:key/aliases", h.followAlias, h.GetSkillAliases)
+	r.GET("/api/v1/skills/:key/translations", h.followAlias, h.GetSkillTranslations)
+	r.GET("/api/v1/skills/:key/levels", h.followAlias, h.GetSkillLevels)
+	r.POST("/api/v1/skills", idempotency.Middleware(h.Db, idempotencyTTL), h.CreateSkill)
+	r.PUT("/api/v1/skills/:key", h.followAlias, h.UpdateSkill)
+	r.PUT("/api/v1/skills/:key/translations/:locale", h.followAlias, h.PutSkillTranslation)
+	r.PUT("/api/v1/skills/:key/levels", h.followAlias, h.PutSkillLevels)
+	r.PATCH("/api/v1/skills/:key/actions/name", h.followAlias, h.UpdateSkillName)
+	r.PATCH("/api/v1/skills/:key/actions/description", h.followAlias, h.UpdateSkillDescription)
+	r.PATCH("/api/v1/skills/:key/actions/logo", h.followAlias, h.UpdateSkillLogo)
+	r.PATCH("/api/v1/skills/:key/actions/tags", h.followAlias, h.UpdateSkillTags)
+	r.PATCH("/api/v1/skills/:key/actions/parent", h.followAlias, h.UpdateSkillParent)
+	r.POST("/api/v1/skills/:key/actions/rename", h.followAlias, idempotency.Middleware(h.Db, idempotencyTTL), h.RenameSkill)
+	r.POST("/api/v1/skills/:key/relations", h.followAlias, idempotency.Middleware(h.Db, idempotencyTTL), h.CreateSkillRelation)
+	r.POST("/api/v1/skills/:key/aliases", h.followAlias, idempotency.Middleware(h.Db, idempotencyTTL), h.CreateSkillAlias)
+	r.DELETE("/api/v1/skills/:key", h.followAlias, h.DeleteSkill)
+	r.DELETE("/api/v1/skills/:key/relations/:type/:related_key", h.followAlias, h.DeleteSkillRelation)
+	r.DELETE("/api/v1/skills/:key/aliases/:alias", h.followAlias, h.DeleteSkillAlias)
+	r.DELETE("/api/v1/skills/:key/translations/:locale", h.followAlias, h.DeleteSkillTranslation)
+	r.GET("/api/v1/tags", h.GetTags)
+	r.GET("/api/v1/tags/:tag/skills", h.GetTagSkills)
+	r.POST("/api/v1/tags/:tag/actions/rename", admin, idempotency.Middleware(h.Db, idempotencyTTL), h.RenameTag)
+	r.POST("/api/v1/tags/actions/merge", admin, idempotency.Middleware(h.Db, idempotencyTTL), h.MergeTags)
 }
@@ -3,20 +3,39 @@ package skill
 import (
 	"database/sql"
 	"net/http"
+	"time"
+
+	"skillsapi/app/events"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Skill struct {
-	Key         string   `json:"key"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Logo        string   `json:"logo"`
-	Tags        []string `json:"tags"`
+	Key         string   `json:"key" yaml:"key"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Logo        string   `json:"logo" yaml:"logo"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	// ParentKey places the skill in the taxonomy; nil for a root.
+	ParentKey *string `json:"parent_key" yaml:"parent_key"`
+
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	CreatedBy string    `json:"created_by" yaml:"created_by"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+	UpdatedBy string    `json:"updated_by" yaml:"updated_by"`
 }
 
 type Handler struct {
-	Db *sql.DB
+	Db     *sql.DB
+	Events *events.Broker
+	// Suggester serves GET /api/v1/skills/suggest; the database when nil.
+	Suggester Suggester
+	// DefaultLocale is the locale of the names and descriptions stored on
+	// skills; DefaultLocale when empty.
+	DefaultLocale string
+	// Admins are the client identities allowed to rename and merge tags.
+	// When empty, any identified client is.
+	Admins []string
 }
 
 func GetPing(c *gin.Context) {
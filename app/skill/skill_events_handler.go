@@ -0,0 +1,82 @@
+package skill
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"skillsapi/app/events"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	EventCreated = "skill.created"
+	EventUpdated = "skill.updated"
+	EventDeleted = "skill.deleted"
+)
+
+// heartbeatInterval keeps idle event streams from being timed out by proxies.
+var heartbeatInterval = 15 * time.Second
+
+// DeletedSkill is the data of a skill.deleted event; the other events carry
+// the skill itself.
+type DeletedSkill struct {
+	Key       string `json:"key"`
+	DeletedBy string `json:"deleted_by"`
+}
+
+// GetSkillEvents streams skill changes as Server-Sent Events. A client that
+// reconnects with Last-Event-ID first receives the events it missed, as far
+// as the broker still has them.
+func (h *Handler) GetSkillEvents(c *gin.Context) {
+	if h.Events == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  "error",
+			"message": "Skill events are not available",
+		})
+		return
+	}
+
+	lastID, _ := strconv.ParseUint(c.GetHeader("Last-Event-ID"), 10, 64)
+	replay, ch, cancel := h.Events.Subscribe(lastID)
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, e := range replay {
+		renderEvent(c, e)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			renderEvent(c, e)
+		case <-heartbeat.C:
+			_, _ = c.Writer.WriteString(": heartbeat\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+func renderEvent(c *gin.Context, e events.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(e.ID, 10),
+		Event: e.Type,
+		Data:  e.Data,
+	})
+}
@@ -0,0 +1,86 @@
+package skill
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"skillsapi/app/events"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSkillEvents(t *testing.T) {
+	heartbeatInterval = 50 * time.Millisecond
+	defer func() { heartbeatInterval = 15 * time.Second }()
+
+	broker := events.NewBroker(10)
+	h := Handler{Events: broker}
+	r := gin.New()
+	r.GET("/api/v1/skills/events", h.GetSkillEvents)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	defer broker.Close()
+
+	missed := broker.Publish(events.Event{Type: EventCreated, Key: "go", Data: Skill{Key: "go", Name: "Go"}})
+	resumeFrom := missed.ID - 1
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/skills/events", nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(resumeFrom, 10))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		var event []string
+		for {
+			line, err := lines.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return event
+			}
+			event = append(event, line)
+		}
+	}
+
+	replayed := readEvent()
+	assert.Equal(t, "id:"+strconv.FormatUint(missed.ID, 10), replayed[0])
+	assert.Equal(t, "event:"+EventCreated, replayed[1])
+	assert.Contains(t, replayed[2], `"key":"go"`)
+
+	assert.Equal(t, []string{": heartbeat"}, readEvent())
+
+	deleted := broker.Publish(events.Event{Type: EventDeleted, Key: "go", Data: DeletedSkill{Key: "go", DeletedBy: "hr-portal"}})
+	live := readEvent()
+	for len(live) == 1 && live[0] == ": heartbeat" {
+		live = readEvent()
+	}
+	assert.Equal(t, []string{
+		"id:" + strconv.FormatUint(deleted.ID, 10),
+		"event:" + EventDeleted,
+		`data:{"key":"go","deleted_by":"hr-portal"}`,
+	}, live)
+}
+
+func TestGetSkillEventsUnavailable(t *testing.T) {
+	h := Handler{}
+	r := gin.New()
+	r.GET("/api/v1/skills/events", h.GetSkillEvents)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/skills/events", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
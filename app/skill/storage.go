@@ -0,0 +1,229 @@
+package skill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrSkillNotFound      = errors.New("skill not found")
+	ErrSkillAlreadyExists = errors.New("skill already exists")
+	ErrInvalidListOptions = errors.New("invalid list options")
+)
+
+var skillColumns = strings.Join(skillFields, ", ")
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SkillPatch struct {
+	Name        *string
+	Description *string
+	Logo        *string
+	Tags        []string
+}
+
+// ListOptions narrows ListSkills. Results are ordered by Sort and then by
+// key. After skips every skill up to and including that key, so it cannot be
+// combined with Sort. Fields limits the columns that are read; the others are
+// left zero. A zero Limit means no limit, and a zero UpdatedSince no lower
+// bound on updated_at. Under keeps the skills anywhere below that key in the
+// taxonomy. Tag also matches the other names of the skill it names, if any,
+// and Search the aliases of each skill.
+type ListOptions struct {
+	Tag          string
+	Search       string
+	Under        string
+	UpdatedSince time.Time
+	Fields       []string
+	Sort         []SortField
+	After        string
+	Limit        int
+	Offset       int
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type Storage struct {
+	Db *sql.DB
+}
+
+func (h *Handler) storage() *Storage {
+	return &Storage{Db: h.Db}
+}
+
+func (s *Storage) ListSkills(ctx context.Context, opts ListOptions) ([]Skill, error) {
+	query, args, err := listQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = skillFields
+	}
+
+	skills := []Skill{}
+	for rows.Next() {
+		skill, err := scanSkillFields(rows, fields)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+func (s *Storage) GetSkill(ctx context.Context, key string) (Skill, error) {
+	row := s.Db.QueryRowContext(ctx, `SELECT `+skillColumns+` FROM skills WHERE key = $1`, key)
+	return scanSkill(row)
+}
+
+// LastModified returns when any skill was last created, changed or deleted.
+func (s *Storage) LastModified(ctx context.Context) (time.Time, error) {
+	var lastModified time.Time
+	err := s.Db.QueryRowContext(ctx, `SELECT last_modified FROM skills_meta`).Scan(&lastModified)
+	return lastModified, err
+}
+
+func (s *Storage) CreateSkill(ctx context.Context, skill Skill) (Skill, error) {
+	row := s.Db.QueryRowContext(ctx, `INSERT INTO skills (key, name, description, logo, tags, parent_key, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (key) DO NOTHING RETURNING `+skillColumns,
+		skill.Key, skill.Name, skill.Description, skill.Logo, pq.Array(skill.Tags), skill.ParentKey, ActorFrom(ctx))
+
+	created, err := scanSkill(row)
+	if errors.Is(err, ErrSkillNotFound) || isUniqueViolation(err) {
+		return Skill{}, ErrSkillAlreadyExists
+	} else if isForeignKeyViolation(err) {
+		return Skill{}, ErrParentNotFound
+	}
+	return created, err
+}
+
+func (s *Storage) UpdateSkill(ctx context.Context, key string, skill UpdateSkill) (Skill, error) {
+	set := `name = $1, description = $2, logo = $3, tags = $4`
+	return executeUpdate(ctx, s.Db, key, set, skill.Name, skill.Description, skill.Logo, pq.Array(skill.Tags))
+}
+
+func (s *Storage) PatchSkill(ctx context.Context, key string, patch SkillPatch) (Skill, error) {
+	set := `name = COALESCE($1, name),
+		description = COALESCE($2, description),
+		logo = COALESCE($3, logo),
+		tags = COALESCE($4, tags)`
+	return executeUpdate(ctx, s.Db, key, set, patch.Name, patch.Description, patch.Logo, pq.Array(patch.Tags))
+}
+
+// DeleteSkill deletes a skill that has no children. The actor is set for the
+// transaction so that the change notification can name who deleted it.
+func (s *Storage) DeleteSkill(ctx context.Context, key string) error {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('skills.actor', $1, true)`, ActorFrom(ctx)); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM skills WHERE key = $1`, key)
+	if isForeignKeyViolation(err) {
+		return ErrSkillHasChildren
+	} else if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSkillNotFound
+	}
+	return tx.Commit()
+}
+
+func listQuery(opts ListOptions) (string, []interface{}, error) {
+	if opts.After != "" && len(opts.Sort) > 0 {
+		return "", nil, ErrInvalidListOptions
+	}
+
+	columns := skillColumns
+	if len(opts.Fields) > 0 {
+		for _, f := range opts.Fields {
+			if !slices.Contains(skillFields, f) {
+				return "", nil, ErrInvalidListOptions
+			}
+		}
+		columns = strings.Join(opts.Fields, ", ")
+	}
+
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if opts.Tag != "" {
+		where = append(where, `tags && ARRAY(`+tagSynonyms(arg(opts.Tag))+`)`)
+	}
+	if opts.Search != "" {
+		p := arg("%" + likeEscaper.Replace(opts.Search) + "%")
+		where = append(where, `(key ILIKE `+p+` OR name ILIKE `+p+` OR description ILIKE `+p+
+			` OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.key = skills.key AND a.alias ILIKE `+p+`))`)
+	}
+	if opts.Under != "" {
+		where = append(where, `key IN (`+descendantKeys(arg(opts.Under))+`)`)
+	}
+	if !opts.UpdatedSince.IsZero() {
+		where = append(where, `updated_at >= `+arg(opts.UpdatedSince))
+	}
+	if opts.After != "" {
+		where = append(where, `key > `+arg(opts.After))
+	}
+
+	query := `SELECT ` + columns + ` FROM skills`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+
+	var orderBy []string
+	for _, sf := range opts.Sort {
+		if !slices.Contains(sortableFields, sf.Field) {
+			return "", nil, ErrInvalidListOptions
+		}
+		if sf.Desc {
+			orderBy = append(orderBy, sf.Field+` DESC`)
+		} else {
+			orderBy = append(orderBy, sf.Field)
+		}
+	}
+	if !slices.ContainsFunc(opts.Sort, func(sf SortField) bool { return sf.Field == "key" }) {
+		orderBy = append(orderBy, `key`)
+	}
+	query += ` ORDER BY ` + strings.Join(orderBy, `, `)
+
+	if opts.Limit > 0 {
+		query += ` LIMIT ` + arg(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query += ` OFFSET ` + arg(opts.Offset)
+	}
+	return query, args, nil
+}
@@ -0,0 +1,71 @@
+package skill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQuery(t *testing.T) {
+	t.Run("should select every column ordered by key by default", func(t *testing.T) {
+		query, args, err := listQuery(ListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT key, name, description, logo, tags, parent_key, created_at, created_by, updated_at, updated_by FROM skills ORDER BY key`, query)
+		assert.Empty(t, args)
+	})
+
+	t.Run("should combine projection, filters, sorting and paging", func(t *testing.T) {
+		query, args, err := listQuery(ListOptions{
+			Fields: []string{"key", "name", "logo"},
+			Tag:    "runtime",
+			Search: "100%_js",
+			Sort:   []SortField{{Field: "name"}, {Field: "key", Desc: true}},
+			Limit:  10,
+			Offset: 20,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT key, name, logo FROM skills`+
+			` WHERE tags && ARRAY(SELECT $1::text UNION SELECT COALESCE((SELECT key FROM skill_aliases WHERE lower(alias) = lower($1)), $1)`+
+			` UNION SELECT alias FROM skill_aliases WHERE key = COALESCE((SELECT key FROM skill_aliases WHERE lower(alias) = lower($1)), $1))`+
+			` AND (key ILIKE $2 OR name ILIKE $2 OR description ILIKE $2`+
+			` OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.key = skills.key AND a.alias ILIKE $2))`+
+			` ORDER BY name, key DESC LIMIT $3 OFFSET $4`, query)
+		assert.Equal(t, []interface{}{"runtime", `%100\%\_js%`, 10, 20}, args)
+	})
+
+	t.Run("should page by key after a cursor", func(t *testing.T) {
+		query, args, err := listQuery(ListOptions{After: "go", Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT key, name, description, logo, tags, parent_key, created_at, created_by, updated_at, updated_by FROM skills WHERE key > $1 ORDER BY key LIMIT $2`, query)
+		assert.Equal(t, []interface{}{"go", 2}, args)
+	})
+
+	t.Run("should filter by update time", func(t *testing.T) {
+		since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		query, args, err := listQuery(ListOptions{UpdatedSince: since, Fields: []string{"key"}, Sort: []SortField{{Field: "updated_at", Desc: true}}})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT key FROM skills WHERE updated_at >= $1 ORDER BY updated_at DESC, key`, query)
+		assert.Equal(t, []interface{}{since}, args)
+	})
+
+	t.Run("should filter by position in the taxonomy", func(t *testing.T) {
+		query, args, err := listQuery(ListOptions{Under: "backend", Fields: []string{"key"}})
+		assert.NoError(t, err)
+		assert.Equal(t, `SELECT key FROM skills WHERE key IN (WITH RECURSIVE descendants AS (SELECT key FROM skills WHERE parent_key = $1`+
+			` UNION ALL SELECT s.key FROM skills s JOIN descendants d ON s.parent_key = d.key)`+
+			` CYCLE key SET is_cycle USING path SELECT key FROM descendants WHERE NOT is_cycle) ORDER BY key`, query)
+		assert.Equal(t, []interface{}{"backend"}, args)
+	})
+
+	t.Run("should reject unknown fields and sort columns", func(t *testing.T) {
+		_, _, err := listQuery(ListOptions{Fields: []string{"key; DROP TABLE skills"}})
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+
+		_, _, err = listQuery(ListOptions{Sort: []SortField{{Field: "description"}}})
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+
+		_, _, err = listQuery(ListOptions{After: "go", Sort: []SortField{{Field: "name"}}})
+		assert.ErrorIs(t, err, ErrInvalidListOptions)
+	})
+}
@@ -0,0 +1,125 @@
+package skill
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSuggestLimit caps the number of suggestions per request.
+const MaxSuggestLimit = 20
+
+// Suggestion is a name that starts with the typed prefix. Kind is "key",
+// "name" or "alias" for a skill, which Key and Name then identify, or "tag".
+// A skill is suggested once, by its key, name or alias in that order of
+// preference.
+type Suggestion struct {
+	Text       string `json:"text"`
+	Kind       string `json:"kind"`
+	Key        string `json:"key,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Popularity int    `json:"popularity"`
+
+	rank int
+}
+
+// Suggester returns the most popular names starting with a prefix, ignoring
+// case.
+type Suggester interface {
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+}
+
+// skillPopularity counts, for the skill aliased s, the skills that build on
+// it: its children, the skills that require it and its related skills.
+const skillPopularity = `((SELECT count(*) FROM skills c WHERE c.parent_key = s.key)` +
+	` + (SELECT count(*) FROM skill_relations r WHERE r.to_key = s.key OR (r.type = 'related' AND r.from_key = s.key)))`
+
+// suggestCandidates selects every key, name, alias and tag whose lower case
+// matches the LIKE pattern $1, with its rank of preference within a skill and
+// its popularity. A tag's popularity is the number of skills carrying it.
+const suggestCandidates = `SELECT m.text, m.kind, m.rank, s.key, s.name, ` + skillPopularity + ` AS popularity
+	FROM (
+		SELECT key AS text, 'key' AS kind, 0 AS rank, key FROM skills WHERE lower(key) LIKE $1
+		UNION ALL SELECT name, 'name', 1, key FROM skills WHERE lower(name) LIKE $1
+		UNION ALL SELECT alias, 'alias', 2, key FROM skill_aliases WHERE lower(alias) LIKE $1
+	) m JOIN skills s ON s.key = m.key
+	WHERE m.text <> ''
+	UNION ALL
+	SELECT tag, 'tag', 3, '', '', count(*) FROM skills, unnest(tags) AS tag WHERE lower(tag) LIKE $1 AND tag <> '' GROUP BY tag`
+
+// Suggest serves suggestions from the database. Keys, names and aliases are
+// matched through prefix indexes; tags are read from every skill.
+func (s *Storage) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	return s.suggestions(ctx, `SELECT text, kind, rank, key, name, popularity FROM (
+			SELECT DISTINCT ON (rank = 3, CASE WHEN rank = 3 THEN text ELSE key END) *
+			FROM (`+suggestCandidates+`) c
+			ORDER BY rank = 3, CASE WHEN rank = 3 THEN text ELSE key END, rank, text COLLATE "C"
+		) best
+		ORDER BY popularity DESC, length(text), text COLLATE "C", key COLLATE "C"
+		LIMIT $2`, likeEscaper.Replace(strings.ToLower(prefix))+"%", limit)
+}
+
+// allSuggestions returns every candidate, for building a SuggestIndex.
+func (s *Storage) allSuggestions(ctx context.Context) ([]Suggestion, error) {
+	return s.suggestions(ctx, suggestCandidates, "%")
+}
+
+func (s *Storage) suggestions(ctx context.Context, query string, args ...interface{}) ([]Suggestion, error) {
+	rows, err := s.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []Suggestion{}
+	for rows.Next() {
+		var sg Suggestion
+		if err := rows.Scan(&sg.Text, &sg.Kind, &sg.rank, &sg.Key, &sg.Name, &sg.Popularity); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, sg)
+	}
+	return suggestions, rows.Err()
+}
+
+// rankSuggestions keeps the preferred candidate of each skill and tag and
+// returns the top limit, in the same order as Storage.Suggest: most popular
+// first, then shortest, then by text and key.
+func rankSuggestions(candidates []Suggestion, limit int) []Suggestion {
+	type identity struct {
+		tag  bool
+		name string
+	}
+	best := map[identity]Suggestion{}
+	for _, c := range candidates {
+		id := identity{tag: c.Kind == "tag", name: c.Key}
+		if id.tag {
+			id.name = c.Text
+		}
+		if b, ok := best[id]; !ok || c.rank < b.rank || (c.rank == b.rank && c.Text < b.Text) {
+			best[id] = c
+		}
+	}
+
+	ranked := make([]Suggestion, 0, len(best))
+	for _, sg := range best {
+		ranked = append(ranked, sg)
+	}
+	slices.SortFunc(ranked, func(a, b Suggestion) int {
+		if a.Popularity != b.Popularity {
+			return b.Popularity - a.Popularity
+		}
+		if la, lb := utf8.RuneCountInString(a.Text), utf8.RuneCountInString(b.Text); la != lb {
+			return la - lb
+		}
+		if c := strings.Compare(a.Text, b.Text); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
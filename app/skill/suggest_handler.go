@@ -0,0 +1,51 @@
+package skill
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSuggestLimit = 10
+
+// GetSkillSuggestions completes the prefix being typed with the most popular
+// skill keys, names, aliases and tags.
+func (h *Handler) GetSkillSuggestions(c *gin.Context) {
+	prefix := c.Query("prefix")
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid prefix parameter",
+		})
+		return
+	}
+	limit, err := parseNonNegative(c, "limit")
+	if err != nil || limit > MaxSuggestLimit {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid limit parameter",
+		})
+		return
+	}
+	if limit == 0 {
+		limit = defaultSuggestLimit
+	}
+
+	suggester := h.Suggester
+	if suggester == nil {
+		suggester = h.storage()
+	}
+	suggestions, err := suggester.Suggest(c.Request.Context(), prefix, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   suggestions,
+	})
+}
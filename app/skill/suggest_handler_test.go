@@ -0,0 +1,65 @@
+package skill
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	suggest := func(query string) []Suggestion {
+		var response struct {
+			Data []Suggestion `json:"data"`
+		}
+		w := serve(http.MethodGet, "/api/v1/skills/suggest?"+query, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"gin","name":"Gin","description":"","logo":"","tags":["golang web"]}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"gorm","name":"GORM","description":"","logo":"","tags":[]}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/go/aliases", `{"alias":"golang"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/gin/relations", `{"type":"requires","key":"go"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills/gorm/relations", `{"type":"requires","key":"go"}`).Code)
+
+	t.Run("should suggest the most popular names first", func(t *testing.T) {
+		assert.Equal(t, []Suggestion{{Text: "go", Kind: "key", Key: "go", Name: "Go", Popularity: 2}}, suggest("prefix=G&limit=1"))
+
+		texts := []string{}
+		for _, s := range suggest("prefix=gol") {
+			texts = append(texts, s.Text+"/"+s.Kind)
+		}
+		assert.Equal(t, []string{"golang/alias", "golang web/tag"}, texts)
+	})
+
+	t.Run("should answer like the in-memory index", func(t *testing.T) {
+		index := NewSuggestIndex(&Storage{Db: db})
+		require.NoError(t, index.Refresh(context.Background()))
+
+		for _, prefix := range []string{"g", "go", "gol", "node", "x"} {
+			want, err := index.Suggest(context.Background(), prefix, 10)
+			require.NoError(t, err)
+			for i := range want {
+				want[i].rank = 0
+			}
+			assert.Equal(t, want, suggest("prefix="+prefix+"&limit=10"), prefix)
+		}
+	})
+
+	t.Run("should validate the parameters", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/skills/suggest", "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/skills/suggest?prefix=g&limit=21", "").Code)
+	})
+}
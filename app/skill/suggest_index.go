@@ -0,0 +1,128 @@
+package skill
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"skillsapi/app/events"
+)
+
+// SuggestIndex serves suggestions from a trie held in memory, rebuilt from
+// the database. It answers like Storage.Suggest, without a round trip.
+type SuggestIndex struct {
+	storage *Storage
+
+	mu   sync.RWMutex
+	root *trieNode
+}
+
+// trieNode stands for the prefix spelled by the path to it. Its top
+// suggestions are ranked when the trie is loaded, so that a lookup does not
+// depend on how many names share the prefix.
+type trieNode struct {
+	children map[rune]*trieNode
+	top      []Suggestion
+}
+
+func NewSuggestIndex(storage *Storage) *SuggestIndex {
+	return &SuggestIndex{storage: storage, root: &trieNode{}}
+}
+
+// Refresh rebuilds the trie from the database.
+func (x *SuggestIndex) Refresh(ctx context.Context) error {
+	candidates, err := x.storage.allSuggestions(ctx)
+	if err != nil {
+		return err
+	}
+	x.load(candidates)
+	return nil
+}
+
+func (x *SuggestIndex) load(candidates []Suggestion) {
+	root := &trieNode{}
+	under := map[*trieNode][]Suggestion{}
+	for _, c := range candidates {
+		node := root
+		under[root] = append(under[root], c)
+		for _, r := range strings.ToLower(c.Text) {
+			child, ok := node.children[r]
+			if !ok {
+				if node.children == nil {
+					node.children = map[rune]*trieNode{}
+				}
+				child = &trieNode{}
+				node.children[r] = child
+			}
+			node = child
+			under[node] = append(under[node], c)
+		}
+	}
+	for node, matches := range under {
+		node.top = rankSuggestions(matches, MaxSuggestLimit)
+	}
+
+	x.mu.Lock()
+	x.root = root
+	x.mu.Unlock()
+}
+
+func (x *SuggestIndex) Suggest(_ context.Context, prefix string, limit int) ([]Suggestion, error) {
+	x.mu.RLock()
+	node := x.root
+	x.mu.RUnlock()
+
+	for _, r := range strings.ToLower(prefix) {
+		if node = node.children[r]; node == nil {
+			return []Suggestion{}, nil
+		}
+	}
+
+	top := node.top
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return append([]Suggestion{}, top...), nil
+}
+
+// Run keeps the index fresh until ctx is done. After a skill change on
+// broker the index is rebuilt on the next tick, and at the latest every
+// maxAge, because alias and relation changes are not on the feed.
+func (x *SuggestIndex) Run(ctx context.Context, broker *events.Broker, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	_, changes, cancel := broker.Subscribe(0)
+	defer func() { cancel() }()
+
+	dirty, refreshed := false, time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-changes:
+			dirty = true
+			if !ok {
+				// The broker drops subscribers that fall behind; subscribe
+				// again on the next tick.
+				changes = nil
+			}
+		case <-ticker.C:
+			if changes == nil {
+				_, changes, cancel = broker.Subscribe(0)
+			}
+			if !dirty && time.Since(refreshed) < maxAge {
+				continue
+			}
+			if err := x.Refresh(ctx); err != nil {
+				if ctx.Err() == nil {
+					slog.Error("Failed to refresh skill suggestions", "error", err)
+				}
+				continue
+			}
+			dirty, refreshed = false, time.Now()
+		}
+	}
+}
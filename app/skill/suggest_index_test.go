@@ -0,0 +1,74 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestIndex(t *testing.T) {
+	x := NewSuggestIndex(nil)
+	x.load([]Suggestion{
+		{Text: "go", Kind: "key", Key: "go", Name: "Go", Popularity: 3, rank: 0},
+		{Text: "Go", Kind: "name", Key: "go", Name: "Go", Popularity: 3, rank: 1},
+		{Text: "golang", Kind: "alias", Key: "go", Name: "Go", Popularity: 3, rank: 2},
+		{Text: "gin", Kind: "key", Key: "gin", Name: "Gin", Popularity: 0, rank: 0},
+		{Text: "Gin", Kind: "name", Key: "gin", Name: "Gin", Popularity: 0, rank: 1},
+		{Text: "graphql", Kind: "key", Key: "graphql", Name: "GraphQL", Popularity: 1, rank: 0},
+		{Text: "GraphQL", Kind: "name", Key: "graphql", Name: "GraphQL", Popularity: 1, rank: 1},
+		{Text: "gorm", Kind: "key", Key: "gorm", Name: "GORM", Popularity: 0, rank: 0},
+		{Text: "GORM", Kind: "name", Key: "gorm", Name: "GORM", Popularity: 0, rank: 1},
+		{Text: "google cloud", Kind: "tag", Popularity: 2, rank: 3},
+	})
+	suggest := func(prefix string, limit int) []string {
+		suggestions, err := x.Suggest(context.Background(), prefix, limit)
+		require.NoError(t, err)
+		texts := []string{}
+		for _, s := range suggestions {
+			texts = append(texts, s.Text)
+		}
+		return texts
+	}
+
+	t.Run("should rank by popularity, then length and text", func(t *testing.T) {
+		assert.Equal(t, []string{"go", "google cloud", "graphql", "gin", "gorm"}, suggest("g", 10))
+	})
+
+	t.Run("should suggest a skill once, by its preferred name", func(t *testing.T) {
+		assert.Equal(t, []string{"go", "google cloud", "gorm"}, suggest("GO", 10))
+		assert.Equal(t, []string{"golang"}, suggest("gola", 10))
+	})
+
+	t.Run("should stop at the limit", func(t *testing.T) {
+		assert.Equal(t, []string{"go", "google cloud"}, suggest("g", 2))
+	})
+
+	t.Run("should keep the top suggestions of every prefix", func(t *testing.T) {
+		x := NewSuggestIndex(nil)
+		var candidates []Suggestion
+		for i := 0; i < MaxSuggestLimit+5; i++ {
+			key := fmt.Sprintf("k%02d", i)
+			candidates = append(candidates, Suggestion{Text: key, Kind: "key", Key: key, Popularity: i})
+		}
+		x.load(candidates)
+
+		suggestions, err := x.Suggest(context.Background(), "k", MaxSuggestLimit)
+		require.NoError(t, err)
+		require.Len(t, suggestions, MaxSuggestLimit)
+		assert.Equal(t, "k24", suggestions[0].Text)
+		assert.Equal(t, "k05", suggestions[MaxSuggestLimit-1].Text)
+	})
+
+	t.Run("should suggest nothing for an unknown prefix", func(t *testing.T) {
+		assert.Equal(t, []string{}, suggest("rust", 10))
+	})
+
+	t.Run("should describe tags without a skill", func(t *testing.T) {
+		suggestions, err := x.Suggest(context.Background(), "goo", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []Suggestion{{Text: "google cloud", Kind: "tag", Popularity: 2, rank: 3}}, suggestions)
+	})
+}
@@ -0,0 +1,105 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/lib/pq"
+)
+
+var ErrTagNotFound = errors.New("tag not found")
+
+// Tag is a tag with the number of skills that carry it.
+type Tag struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// Tags returns every tag in use, the most used first and then by tag.
+func (s *Storage) Tags(ctx context.Context) ([]Tag, error) {
+	rows, err := s.Db.QueryContext(ctx, `SELECT tag, count(*) FROM skills, unnest(tags) AS tag
+		GROUP BY tag ORDER BY count(*) DESC, tag`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// TaggedSkills returns the skills carrying exactly tag, ordered by key.
+// Unlike the tag filter of ListSkills, it does not follow aliases.
+func (s *Storage) TaggedSkills(ctx context.Context, tag string) ([]Skill, error) {
+	rows, err := s.Db.QueryContext(ctx, `SELECT `+skillColumns+` FROM skills WHERE $1 = ANY(tags) ORDER BY key`, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []Skill{}
+	for rows.Next() {
+		skill, err := scanSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(skills) == 0 {
+		return nil, ErrTagNotFound
+	}
+	return skills, nil
+}
+
+// MergeTags replaces the tags in from with into on every skill, in a single
+// statement. A skill that ends up with into twice keeps it once, where it
+// first appeared. Renaming a tag is merging it alone. It fails with
+// ErrTagNotFound when no skill carries any of the tags.
+func (s *Storage) MergeTags(ctx context.Context, from []string, into string) (Tag, error) {
+	from = slices.DeleteFunc(slices.Clone(from), func(t string) bool { return t == into })
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Tag{}, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE skills SET tags = ARRAY(
+			SELECT tag FROM (
+				SELECT CASE WHEN t = ANY($1) THEN $2 ELSE t END AS tag, min(i) AS position
+				FROM unnest(tags) WITH ORDINALITY AS u(t, i)
+				GROUP BY 1
+			) merged ORDER BY position
+		), updated_at = now(), updated_by = $3
+		WHERE tags && $1`, pq.Array(from), into, ActorFrom(ctx))
+	if err != nil {
+		return Tag{}, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return Tag{}, err
+	}
+	if n == 0 && len(from) > 0 {
+		return Tag{}, ErrTagNotFound
+	}
+
+	merged := Tag{Tag: into}
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM skills WHERE $1 = ANY(tags)`, into).Scan(&merged.Count); err != nil {
+		return Tag{}, err
+	}
+	if merged.Count == 0 {
+		return Tag{}, ErrTagNotFound
+	}
+	return merged, tx.Commit()
+}
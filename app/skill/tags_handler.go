@@ -0,0 +1,85 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetTags(c *gin.Context) {
+	tags, err := h.storage().Tags(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   tags,
+	})
+}
+
+func (h *Handler) GetTagSkills(c *gin.Context) {
+	skills, err := h.storage().TaggedSkills(c.Request.Context(), c.Param("tag"))
+	respondTags(c, skills, err, "Internal server error")
+}
+
+func (h *Handler) RenameTag(c *gin.Context) {
+	var rename struct {
+		Tag string `json:"tag" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&rename); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	tag, err := h.storage().MergeTags(RequestContext(c), []string{c.Param("tag")}, rename.Tag)
+	respondTags(c, tag, err, "not be able to rename tag")
+}
+
+// MergeTags replaces several tags with one across every skill, such as
+// "Javascript" and "JS" with "javascript".
+func (h *Handler) MergeTags(c *gin.Context) {
+	var merge struct {
+		Tags []string `json:"tags" binding:"required,min=1,dive,required"`
+		Into string   `json:"into" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	tag, err := h.storage().MergeTags(RequestContext(c), merge.Tags, merge.Into)
+	respondTags(c, tag, err, "not be able to merge tags")
+}
+
+func respondTags(c *gin.Context, data interface{}, err error, errorMessage string) {
+	if errors.Is(err, ErrTagNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Tag not found",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": errorMessage,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   data,
+	})
+}
@@ -0,0 +1,97 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db, Admins: []string{"ops"}})
+	admin := func(method, url, body string) *httptest.ResponseRecorder {
+		return serve(method, url, body, testIdentityHeader, "ops")
+	}
+	tagsOf := func(key string) []string {
+		var response struct {
+			Data Skill `json:"data"`
+		}
+		w := serve(http.MethodGet, "/api/v1/skills/"+key, "")
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data.Tags
+	}
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"react","name":"React","description":"","logo":"","tags":["Javascript","ui"]}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"deno","name":"Deno","description":"","logo":"","tags":["JS","runtime","javascript"]}`).Code)
+
+	t.Run("should list tags with counts", func(t *testing.T) {
+		var response struct {
+			Data []Tag `json:"data"`
+		}
+		w := serve(http.MethodGet, "/api/v1/tags", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 7)
+		assert.Equal(t, []Tag{{Tag: "javascript", Count: 2}, {Tag: "runtime", Count: 2}}, response.Data[:2])
+		counts := map[string]int{}
+		for _, tag := range response.Data[2:] {
+			counts[tag.Tag] = tag.Count
+		}
+		assert.Equal(t, map[string]int{"JS": 1, "Javascript": 1, "programming language": 1, "system": 1, "ui": 1}, counts)
+	})
+
+	t.Run("should list the skills with a tag", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/tags/runtime/skills", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"key":"deno"`)
+		assert.Contains(t, w.Body.String(), `"key":"nodejs"`)
+
+		w = serve(http.MethodGet, "/api/v1/tags/cobol/skills", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Tag not found"}`, w.Body.String())
+	})
+
+	t.Run("should only let admins rename and merge tags", func(t *testing.T) {
+		for _, identity := range []string{"", "hr"} {
+			w := serve(http.MethodPost, "/api/v1/tags/actions/merge", `{"tags":["JS"],"into":"javascript"}`, testIdentityHeader, identity)
+			assert.Equal(t, http.StatusForbidden, w.Code, identity)
+			w = serve(http.MethodPost, "/api/v1/tags/JS/actions/rename", `{"tag":"javascript"}`, testIdentityHeader, identity)
+			assert.Equal(t, http.StatusForbidden, w.Code, identity)
+		}
+		assert.Equal(t, []string{"JS", "runtime", "javascript"}, tagsOf("deno"))
+	})
+
+	t.Run("should merge tags across skills", func(t *testing.T) {
+		w := admin(http.MethodPost, "/api/v1/tags/actions/merge", `{"tags":["Javascript","JS"],"into":"javascript"}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"status":"success","data":{"tag":"javascript","count":3}}`, w.Body.String())
+
+		assert.Equal(t, []string{"javascript", "ui"}, tagsOf("react"))
+		assert.Equal(t, []string{"javascript", "runtime"}, tagsOf("deno"))
+
+		w = admin(http.MethodPost, "/api/v1/tags/actions/merge", `{"tags":["Javascript"],"into":"javascript"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = admin(http.MethodPost, "/api/v1/tags/actions/merge", `{"tags":[],"into":"javascript"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should rename a tag", func(t *testing.T) {
+		w := admin(http.MethodPost, "/api/v1/tags/programming%20language/actions/rename", `{"tag":"language"}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"status":"success","data":{"tag":"language","count":1}}`, w.Body.String())
+		assert.Equal(t, []string{"language", "system"}, tagsOf("go"))
+
+		w = admin(http.MethodPost, "/api/v1/tags/cobol/actions/rename", `{"tag":"legacy"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,195 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrParentNotFound   = errors.New("parent skill not found")
+	ErrSkillCycle       = errors.New("skill cannot be moved under itself or its descendants")
+	ErrSkillHasChildren = errors.New("skill has child skills")
+)
+
+// TreeNode is a skill with the skills below it.
+type TreeNode struct {
+	Skill
+	Children []TreeNode `json:"children"`
+}
+
+// descendantKeys selects the keys of the skills below the skill whose key is
+// bound to the placeholder p. The CYCLE clause keeps a cycle made by hand in
+// the database from recursing forever.
+func descendantKeys(p string) string {
+	return `WITH RECURSIVE descendants AS (SELECT key FROM skills WHERE parent_key = ` + p +
+		` UNION ALL SELECT s.key FROM skills s JOIN descendants d ON s.parent_key = d.key)` +
+		` CYCLE key SET is_cycle USING path SELECT key FROM descendants WHERE NOT is_cycle`
+}
+
+// qualifiedColumns returns the skill columns qualified by a table alias.
+func qualifiedColumns(alias string) string {
+	columns := make([]string, len(skillFields))
+	for i, f := range skillFields {
+		columns[i] = alias + "." + f
+	}
+	return strings.Join(columns, ", ")
+}
+
+// Children returns the skills directly below a skill, ordered by key.
+func (s *Storage) Children(ctx context.Context, key string) ([]Skill, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `SELECT `+skillColumns+` FROM skills WHERE parent_key = $1 ORDER BY key`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := []Skill{}
+	for rows.Next() {
+		skill, err := scanSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, skill)
+	}
+	return children, rows.Err()
+}
+
+// Ancestors returns the skills above a skill, from the root down to its
+// parent.
+func (s *Storage) Ancestors(ctx context.Context, key string) ([]Skill, error) {
+	rows, err := s.Db.QueryContext(ctx, `WITH RECURSIVE ancestors AS (
+			SELECT `+qualifiedColumns("s")+`, 0 AS depth FROM skills s WHERE s.key = $1
+			UNION ALL
+			SELECT `+qualifiedColumns("p")+`, a.depth + 1 FROM skills p JOIN ancestors a ON p.key = a.parent_key
+		) CYCLE key SET is_cycle USING path
+		SELECT `+skillColumns+` FROM ancestors WHERE NOT is_cycle ORDER BY depth DESC`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	path := []Skill{}
+	for rows.Next() {
+		skill, err := scanSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, skill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return nil, ErrSkillNotFound
+	}
+	// The last row is the skill itself.
+	return path[:len(path)-1], nil
+}
+
+// Subtree returns a skill with every skill below it, children ordered by
+// key.
+func (s *Storage) Subtree(ctx context.Context, key string) (TreeNode, error) {
+	rows, err := s.Db.QueryContext(ctx, `WITH RECURSIVE subtree AS (
+			SELECT `+qualifiedColumns("s")+`, 0 AS depth FROM skills s WHERE s.key = $1
+			UNION ALL
+			SELECT `+qualifiedColumns("c")+`, t.depth + 1 FROM skills c JOIN subtree t ON c.parent_key = t.key
+		) CYCLE key SET is_cycle USING path
+		SELECT `+skillColumns+` FROM subtree WHERE NOT is_cycle ORDER BY depth, key`, key)
+	if err != nil {
+		return TreeNode{}, err
+	}
+	defer rows.Close()
+
+	var root *Skill
+	children := map[string][]Skill{}
+	for rows.Next() {
+		skill, err := scanSkill(rows)
+		if err != nil {
+			return TreeNode{}, err
+		}
+		if root == nil {
+			root = &skill
+			continue
+		}
+		children[*skill.ParentKey] = append(children[*skill.ParentKey], skill)
+	}
+	if err := rows.Err(); err != nil {
+		return TreeNode{}, err
+	}
+	if root == nil {
+		return TreeNode{}, ErrSkillNotFound
+	}
+	return buildTree(*root, children), nil
+}
+
+func buildTree(skill Skill, children map[string][]Skill) TreeNode {
+	node := TreeNode{Skill: skill, Children: []TreeNode{}}
+	for _, child := range children[skill.Key] {
+		node.Children = append(node.Children, buildTree(child, children))
+	}
+	return node
+}
+
+// SetParent moves a skill below parent, or to the root when parent is nil.
+// Moves are serialised by an advisory lock, so that two moves that are each
+// fine cannot close a cycle together.
+func (s *Storage) SetParent(ctx context.Context, key string, parent *string) (Skill, error) {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Skill{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('skills_taxonomy'))`); err != nil {
+		return Skill{}, err
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM skills WHERE key = $1)`, key).Scan(&exists); err != nil {
+		return Skill{}, err
+	}
+	if !exists {
+		return Skill{}, ErrSkillNotFound
+	}
+
+	if parent != nil {
+		// The move closes a cycle when the skill is the new parent or one of
+		// its ancestors.
+		var found, cycle bool
+		err := tx.QueryRowContext(ctx, `WITH RECURSIVE ancestors AS (
+				SELECT key, parent_key FROM skills WHERE key = $1
+				UNION ALL
+				SELECT s.key, s.parent_key FROM skills s JOIN ancestors a ON s.key = a.parent_key
+			) CYCLE key SET is_cycle USING path
+			SELECT count(*) > 0, COALESCE(bool_or(key = $2), false) FROM ancestors`, *parent, key).Scan(&found, &cycle)
+		if err != nil {
+			return Skill{}, err
+		}
+		if !found {
+			return Skill{}, ErrParentNotFound
+		}
+		if cycle {
+			return Skill{}, ErrSkillCycle
+		}
+	}
+
+	skill, err := executeUpdate(ctx, tx, key, `parent_key = $1`, parent)
+	if isForeignKeyViolation(err) {
+		return Skill{}, ErrParentNotFound
+	} else if err != nil {
+		return Skill{}, err
+	}
+	return skill, tx.Commit()
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
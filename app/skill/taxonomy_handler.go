@@ -0,0 +1,75 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSkillChildren(c *gin.Context) {
+	children, err := h.storage().Children(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, children, err)
+}
+
+// GetSkillAncestors returns the breadcrumbs of a skill, from the root down to
+// its parent.
+func (h *Handler) GetSkillAncestors(c *gin.Context) {
+	ancestors, err := h.storage().Ancestors(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, ancestors, err)
+}
+
+func (h *Handler) GetSkillSubtree(c *gin.Context) {
+	tree, err := h.storage().Subtree(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, tree, err)
+}
+
+// UpdateSkillParent moves a skill in the taxonomy. A null parent_key makes it
+// a root.
+func (h *Handler) UpdateSkillParent(c *gin.Context) {
+	var body map[string]*string
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+	parent, ok := body["parent_key"]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	skill, err := h.storage().SetParent(RequestContext(c), c.Param("key"), parent)
+	switch {
+	case errors.Is(err, ErrSkillNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+	case errors.Is(err, ErrParentNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Parent skill not found",
+		})
+	case errors.Is(err, ErrSkillCycle):
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Skill cannot be moved under itself or its descendants",
+		})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to update skill parent",
+		})
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   skill,
+		})
+	}
+}
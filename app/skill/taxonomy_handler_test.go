@@ -0,0 +1,116 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxonomy(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	keys := func(w *httptest.ResponseRecorder) []string {
+		var response struct {
+			Data []Skill `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		keys := []string{}
+		for _, s := range response.Data {
+			keys = append(keys, s.Key)
+		}
+		return keys
+	}
+
+	// engineering > backend > go, with nodejs moved under backend below.
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"engineering","name":"Engineering","description":"","logo":"","tags":[]}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/skills", `{"key":"backend","name":"Backend","description":"","logo":"","tags":[],"parent_key":"engineering"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPatch, "/api/v1/skills/go/actions/parent", `{"parent_key":"backend"}`).Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodPatch, "/api/v1/skills/nodejs/actions/parent", `{"parent_key":"backend"}`).Code)
+
+	t.Run("should list children", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/backend/children", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"go", "nodejs"}, keys(w))
+	})
+
+	t.Run("should list ancestors from the root", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/go/ancestors", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"engineering", "backend"}, keys(w))
+
+		w = serve(http.MethodGet, "/api/v1/skills/engineering/ancestors", "")
+		assert.Equal(t, []string{}, keys(w))
+	})
+
+	t.Run("should return a subtree", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/engineering/subtree", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data TreeNode `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "engineering", response.Data.Key)
+		require.Len(t, response.Data.Children, 1)
+		backend := response.Data.Children[0]
+		assert.Equal(t, "backend", backend.Key)
+		require.Len(t, backend.Children, 2)
+		assert.Equal(t, "go", backend.Children[0].Key)
+		assert.Empty(t, backend.Children[0].Children)
+	})
+
+	t.Run("should filter the list by subtree", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills?under=engineering&fields=key", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []string{"backend", "go", "nodejs"}, keys(w))
+	})
+
+	t.Run("should reject moves that close a cycle", func(t *testing.T) {
+		w := serve(http.MethodPatch, "/api/v1/skills/engineering/actions/parent", `{"parent_key":"go"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Skill cannot be moved under itself or its descendants"}`, w.Body.String())
+
+		w = serve(http.MethodPatch, "/api/v1/skills/go/actions/parent", `{"parent_key":"go"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("should reject unknown skills and parents", func(t *testing.T) {
+		w := serve(http.MethodPatch, "/api/v1/skills/go/actions/parent", `{"parent_key":"frontend"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Parent skill not found"}`, w.Body.String())
+
+		w = serve(http.MethodPost, "/api/v1/skills", `{"key":"rust","name":"Rust","description":"","logo":"","tags":[],"parent_key":"frontend"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = serve(http.MethodPatch, "/api/v1/skills/go/actions/parent", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		for _, url := range []string{"/api/v1/skills/rust/children", "/api/v1/skills/rust/ancestors", "/api/v1/skills/rust/subtree"} {
+			assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, url, "").Code, url)
+		}
+	})
+
+	t.Run("should not delete a skill with children", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/api/v1/skills/backend", "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"status":"error","message":"Skill has child skills"}`, w.Body.String())
+	})
+
+	t.Run("should move a skill back to the root", func(t *testing.T) {
+		w := serve(http.MethodPatch, "/api/v1/skills/nodejs/actions/parent", `{"parent_key":null}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"parent_key":null`)
+
+		w = serve(http.MethodGet, "/api/v1/skills/backend/children", "")
+		assert.Equal(t, []string{"go"}, keys(w))
+	})
+}
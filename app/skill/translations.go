@@ -0,0 +1,157 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// Translation is the name and description of a skill in another locale than
+// the default one.
+type Translation struct {
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UpdatedBy   string    `json:"updated_by"`
+}
+
+// Translations returns the translations of a skill, ordered by locale.
+func (s *Storage) Translations(ctx context.Context, key string) ([]Translation, error) {
+	if _, err := s.GetSkill(ctx, key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Db.QueryContext(ctx, `SELECT locale, name, description, updated_at, updated_by
+		FROM skill_translations WHERE key = $1 ORDER BY locale`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []Translation{}
+	for rows.Next() {
+		var t Translation
+		if err := rows.Scan(&t.Locale, &t.Name, &t.Description, &t.UpdatedAt, &t.UpdatedBy); err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	return translations, rows.Err()
+}
+
+// PutTranslation creates or replaces the translation of a skill into
+// t.Locale. The skill is stamped as updated too, so that cached reads of it
+// are revalidated.
+func (s *Storage) PutTranslation(ctx context.Context, key string, t Translation) (Translation, error) {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return Translation{}, err
+	}
+	defer tx.Rollback()
+
+	if err := touchSkill(ctx, tx, key); err != nil {
+		return Translation{}, err
+	}
+	err = tx.QueryRowContext(ctx, `INSERT INTO skill_translations (key, locale, name, description, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key, locale) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = now(), updated_by = EXCLUDED.updated_by
+		RETURNING updated_at, updated_by`, key, t.Locale, t.Name, t.Description, ActorFrom(ctx)).Scan(&t.UpdatedAt, &t.UpdatedBy)
+	if err != nil {
+		return Translation{}, err
+	}
+	return t, tx.Commit()
+}
+
+func (s *Storage) DeleteTranslation(ctx context.Context, key, locale string) error {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM skill_translations WHERE key = $1 AND locale = $2`, key, locale)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTranslationNotFound
+	}
+	if err := touchSkill(ctx, tx, key); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// touchSkill stamps a skill with the time and the actor of ctx.
+func touchSkill(ctx context.Context, db queryRower, key string) error {
+	_, err := executeUpdate(ctx, db, key, `name = name`)
+	return err
+}
+
+// Localize replaces the name and description of each skill, which must have
+// its key, with its translation into the first locale of chain that has one.
+// The default locale ends the chain, since the skill itself is in it. It
+// returns the locale served for each skill.
+func (s *Storage) Localize(ctx context.Context, skills []Skill, chain []string, defaultLocale string) ([]string, error) {
+	served := make([]string, len(skills))
+	for i := range served {
+		served[i] = defaultLocale
+	}
+	for i, locale := range chain {
+		if locale == defaultLocale {
+			chain = chain[:i]
+			break
+		}
+	}
+	if len(chain) == 0 || len(skills) == 0 {
+		return served, nil
+	}
+
+	keys := make([]string, len(skills))
+	for i, skill := range skills {
+		keys[i] = skill.Key
+	}
+	rows, err := s.Db.QueryContext(ctx, `SELECT key, locale, name, description FROM skill_translations
+		WHERE key = ANY($1) AND locale = ANY($2)`, pq.Array(keys), pq.Array(chain))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := map[string]map[string]Translation{}
+	for rows.Next() {
+		var key string
+		var t Translation
+		if err := rows.Scan(&key, &t.Locale, &t.Name, &t.Description); err != nil {
+			return nil, err
+		}
+		if translations[key] == nil {
+			translations[key] = map[string]Translation{}
+		}
+		translations[key][t.Locale] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range skills {
+		for _, locale := range chain {
+			if t, ok := translations[skills[i].Key][locale]; ok {
+				skills[i].Name, skills[i].Description = t.Name, t.Description
+				served[i] = locale
+				break
+			}
+		}
+	}
+	return served, nil
+}
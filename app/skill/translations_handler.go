@@ -0,0 +1,87 @@
+package skill
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSkillTranslations(c *gin.Context) {
+	translations, err := h.storage().Translations(c.Request.Context(), c.Param("key"))
+	respondSkillData(c, translations, err)
+}
+
+// PutSkillTranslation creates or replaces the name and description of a skill
+// in a locale other than the default one, which lives on the skill itself.
+func (h *Handler) PutSkillTranslation(c *gin.Context) {
+	locale, ok := parseLocale(c.Param("locale"))
+	if !ok || locale == h.defaultLocale() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid locale",
+		})
+		return
+	}
+
+	var translation struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&translation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	saved, err := h.storage().PutTranslation(RequestContext(c), c.Param("key"), Translation{
+		Locale:      locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+	})
+	if errors.Is(err, ErrSkillNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Skill not found",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to save translation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   saved,
+	})
+}
+
+func (h *Handler) DeleteSkillTranslation(c *gin.Context) {
+	err := ErrTranslationNotFound
+	if locale, ok := parseLocale(c.Param("locale")); ok {
+		err = h.storage().DeleteTranslation(RequestContext(c), c.Param("key"), locale)
+	}
+	if errors.Is(err, ErrTranslationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Translation not found",
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "not be able to delete translation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Translation deleted",
+	})
+}
@@ -0,0 +1,79 @@
+package skill
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"skillsapi/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslations(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	serve := newTestServer(&Handler{Db: db})
+	name := func(w *httptest.ResponseRecorder) string {
+		var response struct {
+			Data Skill `json:"data"`
+		}
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data.Name
+	}
+
+	t.Run("should save a translation", func(t *testing.T) {
+		w := serve(http.MethodPut, "/api/v1/skills/go/translations/TH", `{"name":"ภาษาโก","description":"ภาษาโปรแกรม"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Data []Translation `json:"data"`
+		}
+		w = serve(http.MethodGet, "/api/v1/skills/go/translations", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "th", response.Data[0].Locale)
+		assert.Equal(t, "ภาษาโก", response.Data[0].Name)
+	})
+
+	t.Run("should serve the requested locale", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/go?lang=th", "")
+		assert.Equal(t, "ภาษาโก", name(w))
+		assert.Equal(t, "th", w.Header().Get("Content-Language"))
+
+		w = serve(http.MethodGet, "/api/v1/skills/go", "", "Accept-Language", "th-TH, en;q=0.5")
+		assert.Equal(t, "ภาษาโก", name(w))
+		assert.Equal(t, "th", w.Header().Get("Content-Language"))
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+
+		w = serve(http.MethodGet, "/api/v1/skills?fields=name&q=go", "", "Accept-Language", "th")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "ภาษาโก")
+		assert.NotContains(t, w.Body.String(), `"key"`)
+	})
+
+	t.Run("should fall back to the default locale", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/skills/go?lang=fr", "")
+		assert.Equal(t, "Go", name(w))
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	})
+
+	t.Run("should validate locales", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/skills/go?lang=!", "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/skills?lang=!", "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/api/v1/skills/go/translations/en", `{"name":"Go"}`).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/skills/nope/translations/th", `{"name":"x"}`).Code)
+	})
+
+	t.Run("should delete a translation", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/api/v1/skills/go/translations/th", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/skills/go/translations/th", "").Code)
+		assert.Equal(t, "Go", name(serve(http.MethodGet, "/api/v1/skills/go?lang=th", "")))
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 type UpdateSkill struct {
@@ -24,6 +23,6 @@ func (h *Handler) UpdateSkill(c *gin.Context) {
 		return
 	}
 
-	query := `UPDATE skills SET name = $1, description = $2, logo = $3, tags = $4 WHERE key = $5`
-	executeUpdate(c, h.Db, query, "not be able to update skill", updatedSkill.Name, updatedSkill.Description, updatedSkill.Logo, pq.Array(updatedSkill.Tags), c.Param("key"))
+	skill, err := h.storage().UpdateSkill(RequestContext(c), c.Param("key"), updatedSkill)
+	respondUpdate(c, skill, err, "not be able to update skill")
 }
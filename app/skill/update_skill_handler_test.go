@@ -7,7 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
 	"skillsapi/database"
 
 	"github.com/gin-gonic/gin"
@@ -19,6 +22,7 @@ func TestUpdateSkill(t *testing.T) {
 	defer db.Close()
 	h := Handler{Db: db}
 	r := gin.Default()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
 
 	r.POST("/api/v1/skills", h.CreateSkill)
 	r.PUT("/api/v1/skills/:key", h.UpdateSkill)
@@ -109,6 +113,34 @@ func TestUpdateSkill(t *testing.T) {
 	})
 }
 
+func TestUpdateSkillRecordsActor(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	h := Handler{Db: db}
+	r := gin.Default()
+	r.Use(func(c *gin.Context) { c.Set(mtls.IdentityKey, "hr-portal") })
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	r.PUT("/api/v1/skills/:key", h.UpdateSkill)
+
+	jsonValue, _ := json.Marshal(UpdateSkill{Name: "Go", Description: "Go", Logo: "go.svg", Tags: []string{"go"}})
+	req, _ := http.NewRequest("PUT", "/api/v1/skills/go", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data Skill `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "seed", response.Data.CreatedBy)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), response.Data.CreatedAt.UTC())
+	assert.Equal(t, "hr-portal", response.Data.UpdatedBy)
+	assert.True(t, response.Data.UpdatedAt.After(response.Data.CreatedAt))
+}
+
 func createSkill(t *testing.T, r *gin.Engine, skill Skill) {
 	t.Helper()
 	jsonValue, _ := json.Marshal(skill)
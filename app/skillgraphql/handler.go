@@ -0,0 +1,52 @@
+package skillgraphql
+
+import (
+	"net/http"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/skill"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+)
+
+type Handler struct {
+	schema graphql.Schema
+}
+
+type request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewHandler(storage *skill.Storage) (*Handler, error) {
+	schema, err := NewSchema(storage)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema}, nil
+}
+
+func SetRouter(r *gin.Engine, h *Handler) {
+	r.POST("/graphql", h.Serve)
+}
+
+func (h *Handler) Serve(c *gin.Context) {
+	var req request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": []gin.H{{"message": "Invalid request payload"}},
+		})
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        skill.WithActor(c.Request.Context(), c.GetString(mtls.IdentityKey)),
+	})
+	c.JSON(http.StatusOK, result)
+}
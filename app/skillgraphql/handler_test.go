@@ -0,0 +1,154 @@
+package skillgraphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"skillsapi/app/skill"
+	"skillsapi/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func newTestRouter(t *testing.T, storage *skill.Storage) *gin.Engine {
+	h, err := NewHandler(storage)
+	require.NoError(t, err)
+
+	r := gin.Default()
+	SetRouter(r, h)
+	return r
+}
+
+func do(t *testing.T, r *gin.Engine, query string, variables map[string]interface{}) response {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestArgumentValidation(t *testing.T) {
+	r := newTestRouter(t, &skill.Storage{})
+
+	t.Run("should reject a page size out of range", func(t *testing.T) {
+		resp := do(t, r, `{ skills(first: 500) { edges { cursor } } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "first must be between 1 and 100", resp.Errors[0].Message)
+	})
+
+	t.Run("should reject a malformed cursor", func(t *testing.T) {
+		resp := do(t, r, `{ skills(after: "not a cursor!") { edges { cursor } } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "invalid cursor", resp.Errors[0].Message)
+	})
+
+	t.Run("should reject a request without a query", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSkillsQuery(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	r := newTestRouter(t, &skill.Storage{Db: db})
+
+	t.Run("should page through skills with cursors", func(t *testing.T) {
+		query := `query($after: String) {
+			skills(first: 1, after: $after) {
+				edges { cursor node { key name } }
+				pageInfo { hasNextPage endCursor }
+			}
+		}`
+
+		resp := do(t, r, query, nil)
+		require.Empty(t, resp.Errors)
+		var page struct {
+			Edges []struct {
+				Node struct {
+					Key  string `json:"key"`
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data["skills"], &page))
+		require.Len(t, page.Edges, 1)
+		assert.Equal(t, "go", page.Edges[0].Node.Key)
+		assert.True(t, page.PageInfo.HasNextPage)
+
+		resp = do(t, r, query, map[string]interface{}{"after": page.PageInfo.EndCursor})
+		require.Empty(t, resp.Errors)
+		require.NoError(t, json.Unmarshal(resp.Data["skills"], &page))
+		require.Len(t, page.Edges, 1)
+		assert.Equal(t, "nodejs", page.Edges[0].Node.Key)
+		assert.False(t, page.PageInfo.HasNextPage)
+	})
+
+	t.Run("should filter skills by tag", func(t *testing.T) {
+		resp := do(t, r, `{ skills(filter: {tag: "runtime"}) { edges { node { key } } } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"edges":[{"node":{"key":"nodejs"}}]}`, string(resp.Data["skills"]))
+	})
+
+	t.Run("should return null for an unknown skill", func(t *testing.T) {
+		resp := do(t, r, `{ skill(key: "unknown") { key } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `null`, string(resp.Data["skill"]))
+	})
+}
+
+func TestSkillMutations(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	r := newTestRouter(t, &skill.Storage{Db: db})
+
+	resp := do(t, r, `mutation {
+		createSkill(input: {key: "graphql", name: "GraphQL", description: "Query language", logo: "graphql.svg", tags: ["api"]}) { key tags }
+	}`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"key":"graphql","tags":["api"]}`, string(resp.Data["createSkill"]))
+
+	resp = do(t, r, `mutation {
+		updateSkill(key: "graphql", input: {name: "GraphQL!", description: "Query language", logo: "graphql.svg", tags: []}) { name tags }
+	}`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"name":"GraphQL!","tags":[]}`, string(resp.Data["updateSkill"]))
+
+	resp = do(t, r, `mutation { deleteSkill(key: "graphql") }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `true`, string(resp.Data["deleteSkill"]))
+
+	resp = do(t, r, `mutation { deleteSkill(key: "graphql") }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "skill not found", resp.Errors[0].Message)
+}
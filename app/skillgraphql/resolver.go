@@ -0,0 +1,125 @@
+package skillgraphql
+
+import (
+	"errors"
+	"fmt"
+
+	"skillsapi/app/skill"
+
+	"github.com/graphql-go/graphql"
+)
+
+type resolver struct {
+	storage *skill.Storage
+}
+
+func (r *resolver) skills(p graphql.ResolveParams) (interface{}, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 1 || first > maxPageSize {
+		return nil, fmt.Errorf("first must be between 1 and %d", maxPageSize)
+	}
+
+	opts := skill.ListOptions{Limit: first + 1}
+	if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+		opts.Tag, _ = filter["tag"].(string)
+		opts.Search, _ = filter["search"].(string)
+		opts.Under, _ = filter["under"].(string)
+	}
+	if after, ok := p.Args["after"].(string); ok {
+		key, err := decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		opts.After = key
+	}
+
+	skills, err := r.storage.ListSkills(p.Context, opts)
+	if err != nil {
+		return nil, errInternal
+	}
+
+	conn := connection{Edges: []edge{}}
+	if len(skills) > first {
+		conn.PageInfo.HasNextPage = true
+		skills = skills[:first]
+	}
+	for _, sk := range skills {
+		conn.Edges = append(conn.Edges, edge{Cursor: encodeCursor(sk.Key), Node: sk})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
+}
+
+func (r *resolver) skill(p graphql.ResolveParams) (interface{}, error) {
+	sk, err := r.storage.ResolveSkill(p.Context, p.Args["key"].(string))
+	if errors.Is(err, skill.ErrSkillNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errInternal
+	}
+	return sk, nil
+}
+
+func (r *resolver) createSkill(p graphql.ResolveParams) (interface{}, error) {
+	input := p.Args["input"].(map[string]interface{})
+
+	newSkill := skill.Skill{
+		Key:         input["key"].(string),
+		Name:        input["name"].(string),
+		Description: input["description"].(string),
+		Logo:        input["logo"].(string),
+		Tags:        toStrings(input["tags"]),
+	}
+	if parent, ok := input["parentKey"].(string); ok {
+		newSkill.ParentKey = &parent
+	}
+
+	created, err := r.storage.CreateSkill(p.Context, newSkill)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return created, nil
+}
+
+func (r *resolver) updateSkill(p graphql.ResolveParams) (interface{}, error) {
+	input := p.Args["input"].(map[string]interface{})
+
+	updated, err := r.storage.UpdateSkill(p.Context, p.Args["key"].(string), skill.UpdateSkill{
+		Name:        input["name"].(string),
+		Description: input["description"].(string),
+		Logo:        input["logo"].(string),
+		Tags:        toStrings(input["tags"]),
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+	return updated, nil
+}
+
+func (r *resolver) deleteSkill(p graphql.ResolveParams) (interface{}, error) {
+	if err := r.storage.DeleteSkill(p.Context, p.Args["key"].(string)); err != nil {
+		return nil, toError(err)
+	}
+	return true, nil
+}
+
+var errInternal = errors.New("internal server error")
+
+func toError(err error) error {
+	if errors.Is(err, skill.ErrSkillNotFound) || errors.Is(err, skill.ErrSkillAlreadyExists) ||
+		errors.Is(err, skill.ErrParentNotFound) || errors.Is(err, skill.ErrSkillHasChildren) {
+		return err
+	}
+	return errInternal
+}
+
+func toStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		strs = append(strs, item.(string))
+	}
+	return strs
+}
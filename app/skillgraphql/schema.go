@@ -0,0 +1,172 @@
+package skillgraphql
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"skillsapi/app/skill"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type connection struct {
+	Edges    []edge
+	PageInfo pageInfo
+}
+
+type edge struct {
+	Cursor string
+	Node   skill.Skill
+}
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+func NewSchema(storage *skill.Storage) (graphql.Schema, error) {
+	nonNullString := graphql.NewNonNull(graphql.String)
+	tagList := graphql.NewList(nonNullString)
+
+	skillType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Skill",
+		Fields: graphql.Fields{
+			"key":         &graphql.Field{Type: nonNullString},
+			"name":        &graphql.Field{Type: nonNullString},
+			"description": &graphql.Field{Type: nonNullString},
+			"logo":        &graphql.Field{Type: nonNullString},
+			"tags":        &graphql.Field{Type: graphql.NewNonNull(tagList)},
+			"parentKey":   &graphql.Field{Type: graphql.String},
+			"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"createdBy":   &graphql.Field{Type: nonNullString},
+			"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedBy":   &graphql.Field{Type: nonNullString},
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SkillEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: nonNullString},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(skillType)},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SkillConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SkillFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"tag":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"search": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"under":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	createInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateSkillInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"key":         &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"name":        &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"description": &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"logo":        &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"tags":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(tagList)},
+			"parentKey":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	updateInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdateSkillInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":        &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"description": &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"logo":        &graphql.InputObjectFieldConfig{Type: nonNullString},
+			"tags":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(tagList)},
+		},
+	})
+
+	r := &resolver{storage: storage}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"skills": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.skills,
+			},
+			"skill": &graphql.Field{
+				Type: skillType,
+				Args: graphql.FieldConfigArgument{
+					"key": &graphql.ArgumentConfig{Type: nonNullString},
+				},
+				Resolve: r.skill,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createSkill": &graphql.Field{
+				Type: graphql.NewNonNull(skillType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInputType)},
+				},
+				Resolve: r.createSkill,
+			},
+			"updateSkill": &graphql.Field{
+				Type: graphql.NewNonNull(skillType),
+				Args: graphql.FieldConfigArgument{
+					"key":   &graphql.ArgumentConfig{Type: nonNullString},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInputType)},
+				},
+				Resolve: r.updateSkill,
+			},
+			"deleteSkill": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"key": &graphql.ArgumentConfig{Type: nonNullString},
+				},
+				Resolve: r.deleteSkill,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func encodeCursor(key string) string {
+	return base64.URLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", errors.New("invalid cursor")
+	}
+	return string(key), nil
+}
@@ -0,0 +1,65 @@
+package skillgrpc
+
+import (
+	"context"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/skill"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Identify attributes the writes of a call to the identity of the client
+// certificate, resolved the same way as for HTTP requests. Subjects that the
+// map does not authorise are refused with PermissionDenied.
+func Identify(m mtls.IdentityMap) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := identify(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// IdentifyStream is Identify for streaming calls such as ListSkills.
+func IdentifyStream(m mtls.IdentityMap) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := identify(ss.Context(), m)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identifiedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// identifiedStream is a server stream whose context carries the actor.
+type identifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identifiedStream) Context() context.Context {
+	return s.ctx
+}
+
+func identify(ctx context.Context, m mtls.IdentityMap) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx, nil
+	}
+
+	identity, ok := m.Resolve(&info.State)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "client certificate is not authorised")
+	}
+	return skill.WithActor(ctx, identity), nil
+}
@@ -0,0 +1,76 @@
+package skillgrpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/skill"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// withCert is the context of a call over TLS with a verified client
+// certificate.
+func withCert(subject pkix.Name) context.Context {
+	cert := &x509.Certificate{Subject: subject}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestIdentify(t *testing.T) {
+	actor := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return skill.ActorFrom(ctx), nil
+	}
+	subject := pkix.Name{CommonName: "hr-portal", Organization: []string{"Acme"}}
+
+	got, err := Identify(nil)(context.Background(), nil, nil, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, skill.AnonymousActor, got)
+
+	got, err = Identify(nil)(withCert(subject), nil, nil, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "hr-portal", got)
+
+	mapped := mtls.IdentityMap{"CN=hr-portal,O=Acme": "hr"}
+	got, err = Identify(mapped)(withCert(subject), nil, nil, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "hr", got)
+
+	_, err = Identify(mapped)(withCert(pkix.Name{CommonName: "intruder"}), nil, nil, actor)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestIdentifyStream(t *testing.T) {
+	var got string
+	actor := func(_ interface{}, ss grpc.ServerStream) error {
+		got = skill.ActorFrom(ss.Context())
+		return nil
+	}
+	mapped := mtls.IdentityMap{"CN=hr-portal,O=Acme": "hr"}
+
+	err := IdentifyStream(mapped)(nil, contextStream{ctx: withCert(pkix.Name{CommonName: "hr-portal", Organization: []string{"Acme"}})}, nil, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "hr", got)
+
+	err = IdentifyStream(mapped)(nil, contextStream{ctx: withCert(pkix.Name{CommonName: "intruder"})}, nil, actor)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
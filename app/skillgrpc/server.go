@@ -0,0 +1,178 @@
+package skillgrpc
+
+import (
+	"context"
+	"errors"
+
+	"skillsapi/app/skill"
+	skillsv1 "skillsapi/proto/skills/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	skillsv1.UnimplementedSkillServiceServer
+	Storage *skill.Storage
+}
+
+func NewServer(storage *skill.Storage, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	skillsv1.RegisterSkillServiceServer(srv, &Server{Storage: storage})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(skillsv1.SkillService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	reflection.Register(srv)
+	return srv
+}
+
+func (s *Server) GetSkill(ctx context.Context, req *skillsv1.GetSkillRequest) (*skillsv1.Skill, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	sk, err := s.Storage.ResolveSkill(ctx, req.GetKey())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(sk), nil
+}
+
+func (s *Server) ListSkills(_ *skillsv1.ListSkillsRequest, stream grpc.ServerStreamingServer[skillsv1.Skill]) error {
+	skills, err := s.Storage.ListSkills(stream.Context(), skill.ListOptions{})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for _, sk := range skills {
+		if err := stream.Send(toProto(sk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) CreateSkill(ctx context.Context, req *skillsv1.CreateSkillRequest) (*skillsv1.Skill, error) {
+	if req.GetSkill().GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "skill.key is required")
+	}
+
+	created, err := s.Storage.CreateSkill(ctx, fromProto(req.GetSkill()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(created), nil
+}
+
+func (s *Server) UpdateSkill(ctx context.Context, req *skillsv1.UpdateSkillRequest) (*skillsv1.Skill, error) {
+	in := req.GetSkill()
+	if in.GetKey() == "" || in.GetName() == "" || in.GetDescription() == "" || in.GetLogo() == "" {
+		return nil, status.Error(codes.InvalidArgument, "skill.key, skill.name, skill.description and skill.logo are required")
+	}
+
+	updated, err := s.Storage.UpdateSkill(ctx, in.GetKey(), skill.UpdateSkill{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Logo:        in.GetLogo(),
+		Tags:        nonNil(in.GetTags()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(updated), nil
+}
+
+func (s *Server) PatchSkill(ctx context.Context, req *skillsv1.PatchSkillRequest) (*skillsv1.Skill, error) {
+	in := req.GetSkill()
+	if in.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "skill.key is required")
+	}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+
+	var patch skill.SkillPatch
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "name":
+			patch.Name = &in.Name
+		case "description":
+			patch.Description = &in.Description
+		case "logo":
+			patch.Logo = &in.Logo
+		case "tags":
+			patch.Tags = nonNil(in.GetTags())
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask path %q is not supported", path)
+		}
+	}
+
+	patched, err := s.Storage.PatchSkill(ctx, in.GetKey(), patch)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(patched), nil
+}
+
+func (s *Server) DeleteSkill(ctx context.Context, req *skillsv1.DeleteSkillRequest) (*skillsv1.DeleteSkillResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	if err := s.Storage.DeleteSkill(ctx, req.GetKey()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &skillsv1.DeleteSkillResponse{}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, skill.ErrSkillNotFound):
+		return status.Error(codes.NotFound, "skill not found")
+	case errors.Is(err, skill.ErrSkillAlreadyExists):
+		return status.Error(codes.AlreadyExists, "skill already exists")
+	case errors.Is(err, skill.ErrSkillHasChildren):
+		return status.Error(codes.FailedPrecondition, "skill has child skills")
+	case errors.Is(err, skill.ErrParentNotFound):
+		return status.Error(codes.InvalidArgument, "parent skill not found")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func toProto(sk skill.Skill) *skillsv1.Skill {
+	return &skillsv1.Skill{
+		Key:         sk.Key,
+		Name:        sk.Name,
+		Description: sk.Description,
+		Logo:        sk.Logo,
+		Tags:        sk.Tags,
+	}
+}
+
+func fromProto(sk *skillsv1.Skill) skill.Skill {
+	return skill.Skill{
+		Key:         sk.GetKey(),
+		Name:        sk.GetName(),
+		Description: sk.GetDescription(),
+		Logo:        sk.GetLogo(),
+		Tags:        nonNil(sk.GetTags()),
+	}
+}
+
+// proto3 cannot tell an empty repeated field from a missing one, and the
+// tags column is NOT NULL, so an empty list always means "no tags".
+func nonNil(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,153 @@
+package skillgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"skillsapi/app/skill"
+	"skillsapi/database"
+	skillsv1 "skillsapi/proto/skills/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func newTestClient(t *testing.T, storage *skill.Storage) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(storage)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestHealthAndValidation(t *testing.T) {
+	conn := newTestClient(t, &skill.Storage{})
+	ctx := context.Background()
+
+	t.Run("should report the skill service as serving", func(t *testing.T) {
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "skills.v1.SkillService"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+
+	client := skillsv1.NewSkillServiceClient(conn)
+	tests := map[string]func() error{
+		"get without key": func() error {
+			_, err := client.GetSkill(ctx, &skillsv1.GetSkillRequest{})
+			return err
+		},
+		"update without name": func() error {
+			_, err := client.UpdateSkill(ctx, &skillsv1.UpdateSkillRequest{Skill: &skillsv1.Skill{Key: "go"}})
+			return err
+		},
+		"patch without mask": func() error {
+			_, err := client.PatchSkill(ctx, &skillsv1.PatchSkillRequest{Skill: &skillsv1.Skill{Key: "go"}})
+			return err
+		},
+		"patch with unknown mask path": func() error {
+			_, err := client.PatchSkill(ctx, &skillsv1.PatchSkillRequest{
+				Skill:      &skillsv1.Skill{Key: "go"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"key"}},
+			})
+			return err
+		},
+	}
+	for name, call := range tests {
+		t.Run("should reject "+name, func(t *testing.T) {
+			assert.Equal(t, codes.InvalidArgument, status.Code(call()))
+		})
+	}
+}
+
+func TestSkillService(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+
+	client := skillsv1.NewSkillServiceClient(newTestClient(t, &skill.Storage{Db: db}))
+	ctx := context.Background()
+
+	t.Run("should get a skill", func(t *testing.T) {
+		got, err := client.GetSkill(ctx, &skillsv1.GetSkillRequest{Key: "go"})
+		require.NoError(t, err)
+		assert.Equal(t, "Go", got.GetName())
+		assert.Equal(t, []string{"programming language", "system"}, got.GetTags())
+	})
+
+	t.Run("should return not found for an unknown skill", func(t *testing.T) {
+		_, err := client.GetSkill(ctx, &skillsv1.GetSkillRequest{Key: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("should stream every skill", func(t *testing.T) {
+		stream, err := client.ListSkills(ctx, &skillsv1.ListSkillsRequest{})
+		require.NoError(t, err)
+
+		var keys []string
+		for {
+			sk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			keys = append(keys, sk.GetKey())
+		}
+		assert.ElementsMatch(t, []string{"go", "nodejs"}, keys)
+	})
+
+	t.Run("should create, patch and delete a skill", func(t *testing.T) {
+		created, err := client.CreateSkill(ctx, &skillsv1.CreateSkillRequest{Skill: &skillsv1.Skill{
+			Key: "grpcTest", Name: "gRPC", Description: "RPC framework", Logo: "grpc.svg", Tags: []string{"rpc"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "gRPC", created.GetName())
+
+		_, err = client.CreateSkill(ctx, &skillsv1.CreateSkillRequest{Skill: &skillsv1.Skill{Key: "grpcTest"}})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		patched, err := client.PatchSkill(ctx, &skillsv1.PatchSkillRequest{
+			Skill:      &skillsv1.Skill{Key: "grpcTest", Name: "gRPC-Go", Description: "ignored"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "gRPC-Go", patched.GetName())
+		assert.Equal(t, "RPC framework", patched.GetDescription())
+
+		_, err = client.DeleteSkill(ctx, &skillsv1.DeleteSkillRequest{Key: "grpcTest"})
+		require.NoError(t, err)
+
+		_, err = client.DeleteSkill(ctx, &skillsv1.DeleteSkillRequest{Key: "grpcTest"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestToStatus(t *testing.T) {
+	tests := map[error]codes.Code{
+		skill.ErrSkillNotFound:      codes.NotFound,
+		skill.ErrSkillAlreadyExists: codes.AlreadyExists,
+		skill.ErrSkillHasChildren:   codes.FailedPrecondition,
+		skill.ErrParentNotFound:     codes.InvalidArgument,
+		io.ErrUnexpectedEOF:         codes.Internal,
+	}
+	for err, code := range tests {
+		assert.Equal(t, code, status.Code(toStatus(err)), err.Error())
+	}
+}
@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/skill"
+
+	"github.com/gin-gonic/gin"
+)
+
+var eventTypes = []string{skill.EventCreated, skill.EventUpdated, skill.EventDeleted}
+
+type CreateWebhook struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+func (h *Handler) GetWebhooks(c *gin.Context) {
+	subscriptions, err := listSubscriptions(c.Request.Context(), h.Db)
+	if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   subscriptions,
+	})
+}
+
+func (h *Handler) GetWebhook(c *gin.Context) {
+	id, ok := pathID(c, "id", "Webhook not found")
+	if !ok {
+		return
+	}
+
+	subscription, err := getSubscription(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		respondError(c, http.StatusNotFound, "Webhook not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   subscription,
+	})
+}
+
+// CreateWebhook subscribes a URL to skill events. Without a secret one is
+// generated; either way it is only returned here.
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhook
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		respondError(c, http.StatusBadRequest, "Invalid webhook URL")
+		return
+	}
+	if err := checkHost(c.Request.Context(), u.Hostname()); err != nil {
+		respondError(c, http.StatusBadRequest, "Webhook URL must be a public address")
+		return
+	}
+	for _, e := range req.Events {
+		if !slices.Contains(eventTypes, e) {
+			respondError(c, http.StatusBadRequest, "Invalid event type")
+			return
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			internalError(c)
+			return
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	actor := c.GetString(mtls.IdentityKey)
+	if actor == "" {
+		actor = skill.AnonymousActor
+	}
+
+	events := req.Events
+	if events == nil {
+		events = []string{}
+	}
+	created, err := createSubscription(c.Request.Context(), h.Db, Subscription{
+		URL:       req.URL,
+		Events:    events,
+		Secret:    secret,
+		CreatedBy: actor,
+	})
+	if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   created,
+	})
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, ok := pathID(c, "id", "Webhook not found")
+	if !ok {
+		return
+	}
+
+	err := deleteSubscription(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		respondError(c, http.StatusNotFound, "Webhook not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Webhook deleted",
+	})
+}
+
+func (h *Handler) GetDeliveries(c *gin.Context) {
+	id, ok := pathID(c, "id", "Webhook not found")
+	if !ok {
+		return
+	}
+
+	deliveries, err := listDeliveries(c.Request.Context(), h.Db, id)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		respondError(c, http.StatusNotFound, "Webhook not found")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   deliveries,
+	})
+}
+
+// RetryDelivery requeues a dead-lettered delivery.
+func (h *Handler) RetryDelivery(c *gin.Context) {
+	id, ok := pathID(c, "id", "Delivery not found")
+	if !ok {
+		return
+	}
+	deliveryID, ok := pathID(c, "delivery_id", "Delivery not found")
+	if !ok {
+		return
+	}
+
+	err := retryDelivery(c.Request.Context(), h.Db, id, deliveryID)
+	if errors.Is(err, ErrDeliveryNotFound) {
+		respondError(c, http.StatusNotFound, "Delivery not found")
+		return
+	} else if errors.Is(err, ErrDeliveryNotDead) {
+		respondError(c, http.StatusConflict, "Only dead deliveries can be retried")
+		return
+	} else if err != nil {
+		internalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Delivery requeued",
+	})
+}
+
+// pathID reads a numeric path parameter; anything else cannot name an
+// existing row, so it is answered with a 404.
+func pathID(c *gin.Context, name, notFound string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusNotFound, notFound)
+		return 0, false
+	}
+	return id, true
+}
+
+func respondError(c *gin.Context, code int, message string) {
+	c.JSON(code, gin.H{
+		"status":  "error",
+		"message": message,
+	})
+}
+
+func internalError(c *gin.Context) {
+	respondError(c, http.StatusInternalServerError, "Internal server error")
+}
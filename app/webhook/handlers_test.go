@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
+	"skillsapi/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter serves h to a client identified as identity, or to an
+// unidentified client when it is empty.
+func newTestRouter(h *Handler, identity string) *gin.Engine {
+	r := gin.New()
+	r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateResponses: true}))
+	r.Use(func(c *gin.Context) {
+		if identity != "" {
+			c.Set(mtls.IdentityKey, identity)
+		}
+	})
+	SetRouter(r, h)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookAccess(t *testing.T) {
+	h := &Handler{Admins: []string{"ops"}}
+
+	for _, identity := range []string{"", "hr"} {
+		w := serve(newTestRouter(h, identity), http.MethodGet, "/api/v1/webhooks", "")
+
+		assert.Equal(t, http.StatusForbidden, w.Code, identity)
+		assert.JSONEq(t, `{"status":"error","message":"Forbidden"}`, w.Body.String(), identity)
+	}
+}
+
+func TestCreateWebhookValidation(t *testing.T) {
+	r := newTestRouter(&Handler{}, "ops")
+
+	tests := map[string]string{
+		`{"events":["skill.created"]}`:                         "Invalid request payload",
+		`{"url":"ftp://example.com/hook"}`:                     "Invalid webhook URL",
+		`{"url":"/hook"}`:                                      "Invalid webhook URL",
+		`{"url":"https://example.com/hook","events":["nope"]}`: "Invalid event type",
+		`{"url":"http://127.0.0.1:8080/hook"}`:                 "Webhook URL must be a public address",
+		`{"url":"http://localhost/hook"}`:                      "Webhook URL must be a public address",
+		`{"url":"http://10.1.2.3/hook"}`:                       "Webhook URL must be a public address",
+		`{"url":"http://169.254.169.254/latest/meta-data"}`:    "Webhook URL must be a public address",
+		`{"url":"http://[::1]/hook"}`:                          "Webhook URL must be a public address",
+	}
+	for body, message := range tests {
+		w := serve(r, http.MethodPost, "/api/v1/webhooks", body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.JSONEq(t, fmt.Sprintf(`{"status":"error","message":%q}`, message), w.Body.String(), body)
+	}
+
+	w := serve(r, http.MethodGet, "/api/v1/webhooks/abc", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebhooks(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	r := newTestRouter(&Handler{Db: db}, "ops")
+
+	w := serve(r, http.MethodPost, "/api/v1/webhooks", `{"url":"https://hr.example.com/hooks/skills","events":["skill.created","skill.deleted"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var created struct {
+		Data Subscription `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Len(t, created.Data.Secret, 64)
+	assert.Equal(t, []string{"skill.created", "skill.deleted"}, created.Data.Events)
+	assert.Equal(t, "ops", created.Data.CreatedBy)
+	path := fmt.Sprintf("/api/v1/webhooks/%d", created.Data.ID)
+
+	w = serve(r, http.MethodGet, path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	w = serve(r, http.MethodGet, "/api/v1/webhooks", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://hr.example.com/hooks/skills")
+
+	_, err := db.Exec(`DELETE FROM skills WHERE key = 'go'`)
+	require.NoError(t, err)
+
+	w = serve(r, http.MethodGet, path+"/deliveries", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"event_type":"skill.deleted"`)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+
+	w = serve(r, http.MethodPost, path+"/deliveries/1/retry", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = serve(r, http.MethodDelete, path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(r, http.MethodGet, path, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
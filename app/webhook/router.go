@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"time"
+
+	"skillsapi/app/idempotency"
+	"skillsapi/app/mtls"
+
+	"github.com/gin-gonic/gin"
+)
+
+const idempotencyTTL = 24 * time.Hour
+
+func SetRouter(r *gin.Engine, h *Handler) {
+	admin := mtls.RequireIdentity(h.Admins...)
+
+	r.GET("/api/v1/webhooks", admin, h.GetWebhooks)
+	r.GET("/api/v1/webhooks/:id", admin, h.GetWebhook)
+	r.POST("/api/v1/webhooks", admin, idempotency.Middleware(h.Db, idempotencyTTL), h.CreateWebhook)
+	r.DELETE("/api/v1/webhooks/:id", admin, h.DeleteWebhook)
+	r.GET("/api/v1/webhooks/:id/deliveries", admin, h.GetDeliveries)
+	r.POST("/api/v1/webhooks/:id/deliveries/:delivery_id/retry", admin, idempotency.Middleware(h.Db, idempotencyTTL), h.RetryDelivery)
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EventHeader     = "X-Skills-Event"
+	DeliveryHeader  = "X-Skills-Delivery"
+	TimestampHeader = "X-Skills-Timestamp"
+	SignatureHeader = "X-Skills-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the X-Skills-Signature of a payload sent at timestamp: the
+// hex HMAC-SHA256, keyed by the subscription secret, of the Unix timestamp,
+// a dot and the body.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks a received signature and rejects payloads signed more than
+// tolerance ago, so that captured requests cannot be replayed later.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) bool {
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := time.Since(time.Unix(sent, 0)); age > tolerance || age < -tolerance {
+		return false
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal(got, mac(secret, timestamp, body))
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "."))
+	h.Write(body)
+	return h.Sum(nil)
+}
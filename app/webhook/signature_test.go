@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"type":"skill.created"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", now, body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, Verify("secret", timestamp, signature, body, time.Minute))
+
+	assert.False(t, Verify("other", timestamp, signature, body, time.Minute), "wrong secret")
+	assert.False(t, Verify("secret", timestamp, signature, []byte(`{}`), time.Minute), "tampered body")
+	assert.False(t, Verify("secret", strconv.FormatInt(now.Unix()-1, 10), signature, body, time.Minute), "tampered timestamp")
+	assert.False(t, Verify("secret", timestamp, signature[len("sha256="):], body, time.Minute), "missing prefix")
+
+	old := now.Add(-time.Hour)
+	assert.False(t, Verify("secret", strconv.FormatInt(old.Unix(), 10), Sign("secret", old, body), body, time.Minute), "stale")
+}
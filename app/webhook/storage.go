@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrDeliveryNotDead      = errors.New("webhook delivery is not dead")
+)
+
+// deliveryLogSize is how many of the latest deliveries are listed per
+// subscription.
+const deliveryLogSize = 50
+
+const subscriptionColumns = `id, url, events, created_at, created_by`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row scanner) (Subscription, error) {
+	var s Subscription
+	var events pq.StringArray
+	err := row.Scan(&s.ID, &s.URL, &events, &s.CreatedAt, &s.CreatedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Subscription{}, ErrSubscriptionNotFound
+	} else if err != nil {
+		return Subscription{}, err
+	}
+	s.Events = []string(events)
+	return s, nil
+}
+
+func createSubscription(ctx context.Context, db *sql.DB, s Subscription) (Subscription, error) {
+	row := db.QueryRowContext(ctx, `INSERT INTO webhook_subscriptions (url, secret, events, created_by)
+		VALUES ($1, $2, $3, $4) RETURNING `+subscriptionColumns,
+		s.URL, s.Secret, pq.Array(s.Events), s.CreatedBy)
+
+	created, err := scanSubscription(row)
+	if err != nil {
+		return Subscription{}, err
+	}
+	created.Secret = s.Secret
+	return created, nil
+}
+
+func listSubscriptions(ctx context.Context, db *sql.DB) ([]Subscription, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []Subscription{}
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions, rows.Err()
+}
+
+func getSubscription(ctx context.Context, db *sql.DB, id int64) (Subscription, error) {
+	row := db.QueryRowContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, id)
+	return scanSubscription(row)
+}
+
+func deleteSubscription(ctx context.Context, db *sql.DB, id int64) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// listDeliveries returns the latest deliveries of a subscription, newest
+// first, each with its attempts in order.
+func listDeliveries(ctx context.Context, db *sql.DB, subscriptionID int64) ([]Delivery, error) {
+	if _, err := getSubscription(ctx, db, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, subscription_id, event_id, event_type, status, attempts,
+			CASE WHEN status = 'pending' THEN next_attempt_at END, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY id DESC LIMIT $2`,
+		subscriptionID, deliveryLogSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	index := map[int64]int{}
+	var ids []int64
+	for rows.Next() {
+		var d Delivery
+		var lastStatusCode sql.NullInt64
+		err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Status, &d.Attempts,
+			&d.NextAttemptAt, &lastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		d.LastStatusCode = intPtr(lastStatusCode)
+		d.Log = []Attempt{}
+		index[d.ID] = len(deliveries)
+		ids = append(ids, d.ID)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return deliveries, nil
+	}
+
+	attempts, err := db.QueryContext(ctx, `SELECT delivery_id, attempted_at, status_code, error, duration_ms
+		FROM webhook_delivery_attempts WHERE delivery_id = ANY($1) ORDER BY id`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer attempts.Close()
+
+	for attempts.Next() {
+		var deliveryID int64
+		var a Attempt
+		var statusCode sql.NullInt64
+		if err := attempts.Scan(&deliveryID, &a.AttemptedAt, &statusCode, &a.Error, &a.DurationMs); err != nil {
+			return nil, err
+		}
+		a.StatusCode = intPtr(statusCode)
+		d := &deliveries[index[deliveryID]]
+		d.Log = append(d.Log, a)
+	}
+	return deliveries, attempts.Err()
+}
+
+// retryDelivery puts a dead delivery back in the queue with a fresh set of
+// attempts.
+func retryDelivery(ctx context.Context, db *sql.DB, subscriptionID, deliveryID int64) error {
+	var status string
+	err := db.QueryRowContext(ctx, `SELECT status FROM webhook_deliveries WHERE id = $1 AND subscription_id = $2`,
+		deliveryID, subscriptionID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDeliveryNotFound
+	} else if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx, `UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE id = $1 AND status = 'dead'`, deliveryID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDeliveryNotDead
+	}
+	return nil
+}
+
+func intPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var ErrBlockedTarget = errors.New("webhook target is not a public address")
+
+// blocked reports whether ip is one webhooks must not be sent to: loopback,
+// private, link-local (which covers cloud metadata endpoints), multicast or
+// unspecified.
+func blocked(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// checkHost rejects a host that is, or resolves to, a blocked address. A host
+// that does not resolve yet is let through; the worker checks every address
+// it dials anyway.
+func checkHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if blocked(ip) {
+			return ErrBlockedTarget
+		}
+		return nil
+	}
+	if host == "localhost" {
+		return ErrBlockedTarget
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if blocked(addr.IP) {
+			return ErrBlockedTarget
+		}
+	}
+	return nil
+}
+
+// newClient is the client webhooks are sent with. It refuses to connect to
+// blocked addresses, so a host that is re-pointed after the webhook is created
+// is still not reached, and it does not follow redirects.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blocked(ip) {
+				return ErrBlockedTarget
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// Subscription receives the skill events it filters on, or every event when
+// Events is empty. Secret is only returned when the subscription is created.
+type Subscription struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// Delivery is one event queued for one subscription, with the log of its
+// attempts.
+type Delivery struct {
+	ID             int64      `json:"id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastStatusCode *int       `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	Log            []Attempt  `json:"log"`
+}
+
+type Attempt struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  *int      `json:"status_code"`
+	Error       string    `json:"error"`
+	DurationMs  int       `json:"duration_ms"`
+}
+
+type Handler struct {
+	Db *sql.DB
+	// Admins are the client identities allowed to manage webhooks. When
+	// empty, any identified client is.
+	Admins []string
+}
@@ -0,0 +1,201 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Worker sends the queued deliveries. Failed deliveries are retried with
+// exponential backoff and dead-lettered after MaxAttempts; every attempt is
+// logged. Several workers, in one or more instances, can share the queue.
+type Worker struct {
+	Db          *sql.DB
+	Client      *http.Client
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	BatchSize   int
+	// Lease is how long a claimed delivery is hidden from other workers
+	// while it is being sent.
+	Lease time.Duration
+}
+
+// due is a claimed delivery with what is needed to send it.
+type due struct {
+	id        int64
+	attempts  int
+	eventID   string
+	eventType string
+	payload   []byte
+	url       string
+	secret    string
+}
+
+func NewWorker(db *sql.DB) *Worker {
+	return &Worker{
+		Db:          db,
+		Client:      newClient(),
+		MaxAttempts: 8,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  6 * time.Hour,
+		BatchSize:   20,
+		Lease:       time.Minute,
+	}
+}
+
+// Run delivers due webhooks every interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Failed to deliver webhooks", "error", err)
+			}
+		}
+	}
+}
+
+// DeliverDue sends one batch of due deliveries concurrently and returns how
+// many were attempted.
+func (w *Worker) DeliverDue(ctx context.Context) (int, error) {
+	batch, err := w.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range batch {
+		wg.Add(1)
+		go func(d due) {
+			defer wg.Done()
+			a := w.send(ctx, d)
+			if ctx.Err() != nil {
+				// Shutting down; the lease expires and the delivery is retried.
+				return
+			}
+			if err := w.record(context.WithoutCancel(ctx), d, a); err != nil {
+				slog.Error("Failed to record webhook attempt", "delivery", d.id, "error", err)
+			}
+		}(d)
+	}
+	wg.Wait()
+	return len(batch), nil
+}
+
+func (w *Worker) claim(ctx context.Context) ([]due, error) {
+	rows, err := w.Db.QueryContext(ctx, `UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + make_interval(secs => $1)
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY id LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.attempts, d.event_id, d.event_type, d.payload, s.url, s.secret`,
+		w.Lease.Seconds(), w.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.id, &d.attempts, &d.eventID, &d.eventType, &d.payload, &d.url, &d.secret); err != nil {
+			return nil, err
+		}
+		batch = append(batch, d)
+	}
+	return batch, rows.Err()
+}
+
+// send posts a delivery to its subscriber. Any 2xx response counts as
+// delivered.
+func (w *Worker) send(ctx context.Context, d due) (a Attempt) {
+	start := time.Now()
+	a.AttemptedAt = start
+	defer func() { a.DurationMs = int(time.Since(start).Milliseconds()) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.payload))
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "skillsapi-webhooks")
+	req.Header.Set(EventHeader, d.eventType)
+	req.Header.Set(DeliveryHeader, d.eventID)
+	req.Header.Set(TimestampHeader, fmt.Sprint(start.Unix()))
+	req.Header.Set(SignatureHeader, Sign(d.secret, start, d.payload))
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	a.StatusCode = &resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		a.Error = resp.Status
+	}
+	return a
+}
+
+func (w *Worker) record(ctx context.Context, d due, a Attempt) error {
+	tx, err := w.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO webhook_delivery_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)`, d.id, a.AttemptedAt, a.StatusCode, a.Error, a.DurationMs)
+	if err != nil {
+		return err
+	}
+
+	attempts := d.attempts + 1
+	switch {
+	case a.Error == "":
+		_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET status = 'delivered', attempts = $1, last_status_code = $2, last_error = '', delivered_at = now()
+			WHERE id = $3`, attempts, a.StatusCode, d.id)
+	case attempts >= w.MaxAttempts:
+		_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET status = 'dead', attempts = $1, last_status_code = $2, last_error = $3
+			WHERE id = $4`, attempts, a.StatusCode, a.Error, d.id)
+	default:
+		_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET attempts = $1, last_status_code = $2, last_error = $3, next_attempt_at = now() + make_interval(secs => $4)
+			WHERE id = $5`, attempts, a.StatusCode, a.Error, w.backoff(attempts).Seconds(), d.id)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// backoff is the delay after the given number of failed attempts: the base
+// delay doubled for every attempt after the first, up to MaxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.BaseBackoff
+	for i := 1; i < attempts && delay < w.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, w.MaxBackoff)
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"skillsapi/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver records the webhooks it is sent and answers with status.
+type receiver struct {
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(rc.status)
+}
+
+func TestBackoff(t *testing.T) {
+	w := &Worker{BaseBackoff: 30 * time.Second, MaxBackoff: 10 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, w.backoff(1))
+	assert.Equal(t, time.Minute, w.backoff(2))
+	assert.Equal(t, 4*time.Minute, w.backoff(4))
+	assert.Equal(t, 10*time.Minute, w.backoff(6))
+	assert.Equal(t, 10*time.Minute, w.backoff(60))
+}
+
+func TestSend(t *testing.T) {
+	rc := &receiver{status: http.StatusNoContent}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	w := NewWorker(nil)
+	d := due{id: 1, eventID: "b6c1", eventType: "skill.created", payload: []byte(`{"type":"skill.created"}`), url: srv.URL, secret: "secret"}
+
+	a := w.send(context.Background(), d)
+	assert.Nil(t, a.StatusCode)
+	assert.Contains(t, a.Error, ErrBlockedTarget.Error())
+	assert.Empty(t, rc.requests)
+
+	// The test server listens on loopback, which the worker's own client
+	// refuses to dial.
+	w.Client = srv.Client()
+	a = w.send(context.Background(), d)
+	assert.Empty(t, a.Error)
+	assert.Equal(t, http.StatusNoContent, *a.StatusCode)
+
+	require.Len(t, rc.requests, 1)
+	r := rc.requests[0]
+	assert.Equal(t, "skill.created", r.Header.Get(EventHeader))
+	assert.Equal(t, "b6c1", r.Header.Get(DeliveryHeader))
+	assert.True(t, Verify("secret", r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), rc.bodies[0], time.Minute))
+
+	rc.status = http.StatusServiceUnavailable
+	a = w.send(context.Background(), d)
+	assert.Equal(t, "503 Service Unavailable", a.Error)
+
+	d.url = "http://127.0.0.1:1"
+	a = w.send(context.Background(), d)
+	assert.Nil(t, a.StatusCode)
+	assert.NotEmpty(t, a.Error)
+}
+
+func TestSendRedirect(t *testing.T) {
+	rc := &receiver{status: http.StatusOK}
+	target := httptest.NewServer(rc)
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	w := NewWorker(nil)
+	w.Client = srv.Client()
+	w.Client.CheckRedirect = newClient().CheckRedirect
+
+	a := w.send(context.Background(), due{eventType: "skill.created", payload: []byte(`{}`), url: srv.URL})
+	assert.Equal(t, http.StatusTemporaryRedirect, *a.StatusCode)
+	assert.NotEmpty(t, a.Error)
+	assert.Empty(t, rc.requests)
+}
+
+func TestDeliverDue(t *testing.T) {
+	database.ResetDB()
+
+	db := database.NewPostgres()
+	defer db.Close()
+	ctx := context.Background()
+
+	rc := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	all, err := createSubscription(ctx, db, Subscription{URL: srv.URL, Secret: "secret", Events: []string{}})
+	require.NoError(t, err)
+	deletesOnly, err := createSubscription(ctx, db, Subscription{URL: srv.URL + "/deleted", Secret: "secret", Events: []string{"skill.deleted"}})
+	require.NoError(t, err)
+
+	_, err = db.Exec(`UPDATE skills SET name = 'Golang' WHERE key = 'go'`)
+	require.NoError(t, err)
+
+	w := NewWorker(db)
+	w.Client = srv.Client()
+	w.MaxAttempts = 2
+	n, err := w.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, rc.bodies, 1)
+	assert.Contains(t, string(rc.bodies[0]), `"type": "skill.updated"`)
+	assert.Contains(t, string(rc.bodies[0]), `"name": "Golang"`)
+
+	deliveries, err := listDeliveries(ctx, db, all.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, StatusDelivered, deliveries[0].Status)
+	assert.Len(t, deliveries[0].Log, 1)
+
+	t.Run("should dead-letter a delivery that keeps failing", func(t *testing.T) {
+		rc.status = http.StatusInternalServerError
+		_, err := db.Exec(`DELETE FROM skills WHERE key = 'nodejs'`)
+		require.NoError(t, err)
+
+		w.BaseBackoff = 0
+		for i := 0; i < w.MaxAttempts; i++ {
+			_, err := w.DeliverDue(ctx)
+			require.NoError(t, err)
+		}
+
+		deliveries, err := listDeliveries(ctx, db, deletesOnly.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, StatusDead, deliveries[0].Status)
+		assert.Equal(t, 2, deliveries[0].Attempts)
+		assert.Len(t, deliveries[0].Log, 2)
+
+		require.NoError(t, retryDelivery(ctx, db, deletesOnly.ID, deliveries[0].ID))
+		assert.ErrorIs(t, retryDelivery(ctx, db, deletesOnly.ID, deliveries[0].ID), ErrDeliveryNotDead)
+	})
+}
@@ -20,7 +20,7 @@ func ResetDB() {
 	db := NewPostgres()
 	defer db.Close()
 
-	_, err := db.Exec("DROP TABLE if exists skills CASCADE;")
+	_, err := db.Exec("DROP TABLE if exists skills, skills_meta, idempotency_keys, webhook_subscriptions, webhook_deliveries, webhook_delivery_attempts, outbox, skill_relations, skill_aliases, skill_translations, skill_levels, people, person_skills, endorsements, endorsement_log CASCADE;")
 	if err != nil {
 		log.Panic(err)
 	}
@@ -31,22 +31,317 @@ func ResetDB() {
         name TEXT NOT NULL DEFAULT '',
         description TEXT NOT NULL DEFAULT '',
         logo TEXT NOT NULL DEFAULT '',
-        tags TEXT[] NOT NULL DEFAULT '{}'
+        tags TEXT[] NOT NULL DEFAULT '{}',
+        parent_key TEXT REFERENCES skills (key) ON UPDATE CASCADE,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT '',
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        updated_by TEXT NOT NULL DEFAULT '',
+        CONSTRAINT skills_parent_not_self CHECK (parent_key <> key)
     );
-    INSERT INTO skills (key, name, description, logo, tags)
+    CREATE INDEX skills_updated_at_idx ON skills (updated_at);
+    CREATE INDEX skills_parent_key_idx ON skills (parent_key);
+
+    CREATE TABLE IF NOT EXISTS skill_relations (
+        from_key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+        to_key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+        type TEXT NOT NULL CHECK (type IN ('requires', 'related')),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT '',
+        PRIMARY KEY (from_key, type, to_key),
+        CHECK (from_key <> to_key),
+        CHECK (type <> 'related' OR from_key < to_key COLLATE "C")
+    );
+    CREATE INDEX skill_relations_to_key_idx ON skill_relations (to_key, type);
+
+    CREATE TABLE IF NOT EXISTS skill_aliases (
+        alias TEXT PRIMARY KEY,
+        key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT ''
+    );
+    CREATE UNIQUE INDEX skill_aliases_lower_alias_idx ON skill_aliases (lower(alias));
+    CREATE INDEX skill_aliases_key_idx ON skill_aliases (key);
+    CREATE INDEX skills_lower_key_idx ON skills (lower(key));
+    CREATE INDEX skills_lower_key_pattern_idx ON skills (lower(key) text_pattern_ops);
+    CREATE INDEX skills_lower_name_pattern_idx ON skills (lower(name) text_pattern_ops);
+    CREATE INDEX skill_aliases_lower_alias_pattern_idx ON skill_aliases (lower(alias) text_pattern_ops);
+
+    CREATE OR REPLACE FUNCTION check_skill_alias() RETURNS trigger AS $$
+    BEGIN
+        PERFORM pg_advisory_xact_lock(hashtext('skill_name:' || lower(NEW.alias)));
+        IF EXISTS (SELECT 1 FROM skills WHERE lower(key) = lower(NEW.alias)) THEN
+            RAISE EXCEPTION 'alias "%" is a skill key', NEW.alias USING ERRCODE = 'unique_violation';
+        END IF;
+        RETURN NEW;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skill_aliases_check
+        BEFORE INSERT OR UPDATE OF alias ON skill_aliases
+        FOR EACH ROW EXECUTE FUNCTION check_skill_alias();
+
+    CREATE OR REPLACE FUNCTION check_skill_key() RETURNS trigger AS $$
+    BEGIN
+        PERFORM pg_advisory_xact_lock(hashtext('skill_name:' || lower(NEW.key)));
+        IF EXISTS (SELECT 1 FROM skill_aliases WHERE lower(alias) = lower(NEW.key)) THEN
+            RAISE EXCEPTION 'key "%" is a skill alias', NEW.key USING ERRCODE = 'unique_violation';
+        END IF;
+        RETURN NEW;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skills_check_key
+        BEFORE INSERT OR UPDATE OF key ON skills
+        FOR EACH ROW EXECUTE FUNCTION check_skill_key();
+
+    CREATE TABLE IF NOT EXISTS skill_translations (
+        key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+        locale TEXT NOT NULL,
+        name TEXT NOT NULL,
+        description TEXT NOT NULL DEFAULT '',
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        updated_by TEXT NOT NULL DEFAULT '',
+        PRIMARY KEY (key, locale)
+    );
+
+    CREATE TABLE IF NOT EXISTS skill_levels (
+        key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+        level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 10),
+        name TEXT NOT NULL CHECK (name <> ''),
+        description TEXT NOT NULL DEFAULT '',
+        PRIMARY KEY (key, level)
+    );
+
+    CREATE TABLE IF NOT EXISTS people (
+        id BIGSERIAL PRIMARY KEY,
+        name TEXT NOT NULL,
+        email TEXT NOT NULL DEFAULT '',
+        identity TEXT NOT NULL UNIQUE,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT '',
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        updated_by TEXT NOT NULL DEFAULT ''
+    );
+
+    CREATE TABLE IF NOT EXISTS person_skills (
+        person_id BIGINT NOT NULL,
+        skill_key TEXT NOT NULL,
+        level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 10),
+        last_used DATE,
+        interested BOOLEAN NOT NULL DEFAULT false,
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        updated_by TEXT NOT NULL DEFAULT '',
+        PRIMARY KEY (person_id, skill_key),
+        CONSTRAINT person_skills_person_fkey FOREIGN KEY (person_id) REFERENCES people (id) ON DELETE CASCADE,
+        CONSTRAINT person_skills_skill_fkey FOREIGN KEY (skill_key) REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE
+    );
+    CREATE INDEX person_skills_skill_key_idx ON person_skills (skill_key, level);
+
+    CREATE TABLE IF NOT EXISTS endorsements (
+        person_id BIGINT NOT NULL,
+        skill_key TEXT NOT NULL,
+        endorser_id BIGINT NOT NULL,
+        comment TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT '',
+        PRIMARY KEY (person_id, skill_key, endorser_id),
+        CONSTRAINT endorsements_claim_fkey FOREIGN KEY (person_id, skill_key)
+            REFERENCES person_skills (person_id, skill_key) ON UPDATE CASCADE ON DELETE CASCADE,
+        CONSTRAINT endorsements_endorser_fkey FOREIGN KEY (endorser_id) REFERENCES people (id) ON DELETE CASCADE,
+        CONSTRAINT endorsements_not_self CHECK (endorser_id <> person_id)
+    );
+    CREATE INDEX endorsements_endorser_idx ON endorsements (endorser_id, created_at);
+    CREATE INDEX endorsements_skill_key_idx ON endorsements (skill_key);
+
+    CREATE TABLE IF NOT EXISTS endorsement_log (
+        endorser_id BIGINT NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+    CREATE INDEX endorsement_log_endorser_idx ON endorsement_log (endorser_id, created_at);
+    INSERT INTO skills (key, name, description, logo, tags, created_at, created_by, updated_at, updated_by)
     VALUES (
         'go',
         'Go',
         'Go is a statically typed, compiled programming language designed at Google.',
         'https://upload.wikimedia.org/wikipedia/commons/0/05/Go_Logo_Blue.svg',
-        '{programming language, system}'
+        '{programming language, system}',
+        '2024-01-01T00:00:00Z', 'seed', '2024-01-01T00:00:00Z', 'seed'
     ),
     (
         'nodejs',
         'Node.js',
         'Node.js is an open-source, cross-platform, JavaScript runtime environment that executes JavaScript code outside of a browser.',
         'https://upload.wikimedia.org/wikipedia/commons/d/d9/Node.js_logo.svg',
-        '{runtime, javascript}');
+        '{runtime, javascript}',
+        '2024-01-01T00:00:00Z', 'seed', '2024-01-01T00:00:00Z', 'seed');
+
+    CREATE TABLE IF NOT EXISTS skills_meta (
+        id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+        last_modified TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+    INSERT INTO skills_meta DEFAULT VALUES;
+
+    CREATE OR REPLACE FUNCTION touch_skills_meta() RETURNS trigger AS $$
+    BEGIN
+        UPDATE skills_meta SET last_modified = now();
+        RETURN NULL;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skills_touch_meta
+        AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON skills
+        FOR EACH STATEMENT EXECUTE FUNCTION touch_skills_meta();
+
+    CREATE OR REPLACE FUNCTION notify_skill_change() RETURNS trigger AS $$
+    BEGIN
+        IF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+            PERFORM pg_notify('skill_changes', json_build_object('op', 'DELETE', 'key', OLD.key, 'actor', NEW.updated_by)::text);
+            PERFORM pg_notify('skill_changes', json_build_object('op', 'INSERT', 'key', NEW.key, 'actor', NEW.updated_by)::text);
+        ELSIF TG_OP = 'DELETE' THEN
+            PERFORM pg_notify('skill_changes', json_build_object(
+                'op', TG_OP,
+                'key', OLD.key,
+                'actor', COALESCE(current_setting('skills.actor', true), '')
+            )::text);
+        ELSE
+            PERFORM pg_notify('skill_changes', json_build_object(
+                'op', TG_OP,
+                'key', NEW.key,
+                'actor', COALESCE(current_setting('skills.actor', true), '')
+            )::text);
+        END IF;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skills_notify_change
+        AFTER INSERT OR UPDATE OR DELETE ON skills
+        FOR EACH ROW EXECUTE FUNCTION notify_skill_change();
+
+    CREATE TABLE IF NOT EXISTS idempotency_keys (
+        identity TEXT NOT NULL DEFAULT '',
+        key TEXT NOT NULL,
+        request_hash TEXT NOT NULL,
+        status_code INTEGER,
+        content_type TEXT NOT NULL DEFAULT '',
+        response_body BYTEA,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        expires_at TIMESTAMPTZ NOT NULL,
+        PRIMARY KEY (identity, key)
+    );
+
+    CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+        id BIGSERIAL PRIMARY KEY,
+        url TEXT NOT NULL,
+        secret TEXT NOT NULL,
+        events TEXT[] NOT NULL DEFAULT '{}',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        created_by TEXT NOT NULL DEFAULT ''
+    );
+
+    CREATE TABLE IF NOT EXISTS webhook_deliveries (
+        id BIGSERIAL PRIMARY KEY,
+        subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+        event_id UUID NOT NULL,
+        event_type TEXT NOT NULL,
+        payload JSONB NOT NULL,
+        status TEXT NOT NULL DEFAULT 'pending',
+        attempts INTEGER NOT NULL DEFAULT 0,
+        next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        last_status_code INTEGER,
+        last_error TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        delivered_at TIMESTAMPTZ
+    );
+
+    CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+    CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, id);
+
+    CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+        id BIGSERIAL PRIMARY KEY,
+        delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+        attempted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+        status_code INTEGER,
+        error TEXT NOT NULL DEFAULT '',
+        duration_ms INTEGER NOT NULL
+    );
+
+    CREATE INDEX IF NOT EXISTS webhook_delivery_attempts_delivery_idx ON webhook_delivery_attempts (delivery_id, id);
+
+    CREATE OR REPLACE FUNCTION enqueue_webhook_event(change_type TEXT, data JSONB) RETURNS void AS $$
+    DECLARE
+        event JSONB := jsonb_build_object(
+            'id', gen_random_uuid(),
+            'type', change_type,
+            'occurred_at', now(),
+            'data', data
+        );
+    BEGIN
+        INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+        SELECT id, (event->>'id')::uuid, change_type, event
+        FROM webhook_subscriptions
+        WHERE cardinality(events) = 0 OR change_type = ANY(events);
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+    BEGIN
+        IF TG_OP = 'INSERT' THEN
+            PERFORM enqueue_webhook_event('skill.created', to_jsonb(NEW));
+        ELSIF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+            PERFORM enqueue_webhook_event('skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', NEW.updated_by));
+            PERFORM enqueue_webhook_event('skill.created', to_jsonb(NEW));
+        ELSIF TG_OP = 'UPDATE' THEN
+            PERFORM enqueue_webhook_event('skill.updated', to_jsonb(NEW));
+        ELSE
+            PERFORM enqueue_webhook_event('skill.deleted',
+                jsonb_build_object('key', OLD.key, 'deleted_by', COALESCE(current_setting('skills.actor', true), '')));
+        END IF;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skills_enqueue_webhooks
+        AFTER INSERT OR UPDATE OR DELETE ON skills
+        FOR EACH ROW EXECUTE FUNCTION enqueue_webhook_deliveries();
+
+    CREATE TABLE IF NOT EXISTS outbox (
+        id BIGSERIAL PRIMARY KEY,
+        aggregate_key TEXT NOT NULL,
+        event_type TEXT NOT NULL,
+        payload JSONB NOT NULL,
+        actor TEXT NOT NULL DEFAULT '',
+        occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+    CREATE INDEX IF NOT EXISTS outbox_aggregate_key_idx ON outbox (aggregate_key, id);
+
+    CREATE OR REPLACE FUNCTION write_skill_outbox() RETURNS trigger AS $$
+    DECLARE
+        deleted_by TEXT := COALESCE(current_setting('skills.actor', true), '');
+    BEGIN
+        IF TG_OP = 'INSERT' THEN
+            INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+            VALUES (NEW.key, 'skill.created', to_jsonb(NEW), NEW.updated_by);
+        ELSIF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+            INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+            VALUES (OLD.key, 'skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', NEW.updated_by), NEW.updated_by),
+                (NEW.key, 'skill.created', to_jsonb(NEW), NEW.updated_by);
+        ELSIF TG_OP = 'UPDATE' THEN
+            INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+            VALUES (NEW.key, 'skill.updated', to_jsonb(NEW), NEW.updated_by);
+        ELSE
+            INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+            VALUES (OLD.key, 'skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', deleted_by), deleted_by);
+        END IF;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    CREATE TRIGGER skills_write_outbox
+        AFTER INSERT OR UPDATE OR DELETE ON skills
+        FOR EACH ROW EXECUTE FUNCTION write_skill_outbox();
 `)
 
 	if err != nil {
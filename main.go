@@ -5,9 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os/signal"
+	"skillsapi/app/broker"
+	"skillsapi/app/compress"
+	"skillsapi/app/events"
+	"skillsapi/app/idempotency"
+	"skillsapi/app/mtls"
+	"skillsapi/app/openapi"
+	"skillsapi/app/outbox"
+	"skillsapi/app/person"
 	"skillsapi/app/skill"
+	"skillsapi/app/skillgraphql"
+	"skillsapi/app/skillgrpc"
+	"skillsapi/app/webhook"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,6 +28,8 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
@@ -33,9 +48,66 @@ func main() {
 		log.Panic(err)
 	}
 
-	h := &skill.Handler{Db: db}
+	go idempotency.PurgeExpired(ctx, db, time.Hour)
+	go webhook.NewWorker(db).Run(ctx, time.Second)
+
+	publisher, err := broker.New(broker.Config{
+		Kind:          os.Getenv("EVENT_PUBLISHER"),
+		NATSURL:       os.Getenv("NATS_URL"),
+		NATSSubject:   os.Getenv("NATS_SUBJECT"),
+		NATSJetStream: os.Getenv("NATS_JETSTREAM") == "true",
+		KafkaBrokers:  strings.FieldsFunc(os.Getenv("KAFKA_BROKERS"), func(r rune) bool { return r == ',' }),
+		KafkaTopic:    os.Getenv("KAFKA_TOPIC"),
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	defer publisher.Close()
+	go outbox.NewRelay(db, publisher).Run(ctx, time.Second)
+
+	var identities mtls.IdentityMap
+	if name := os.Getenv("TLS_IDENTITY_MAP_FILE"); name != "" {
+		identities, err = mtls.LoadIdentityMap(name)
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+
+	admins := strings.FieldsFunc(os.Getenv("ADMIN_IDENTITIES"), func(r rune) bool { return r == ',' })
+
+	changes := events.NewBroker(1000)
+	storage := &skill.Storage{Db: db}
+	go func() {
+		if err := skill.PublishChanges(ctx, os.Getenv("DATABASE_URL"), storage, changes); err != nil {
+			slog.Error("Stopped publishing skill changes", "error", err)
+		}
+	}()
+
+	h := &skill.Handler{Db: db, Events: changes, DefaultLocale: os.Getenv("DEFAULT_LOCALE"), Admins: admins}
+	if os.Getenv("SUGGEST_INDEX") == "memory" {
+		index := skill.NewSuggestIndex(storage)
+		if err := index.Refresh(ctx); err != nil {
+			log.Panic(err)
+		}
+		go index.Run(ctx, changes, time.Second, time.Minute)
+		h.Suggester = index
+	}
 	r := gin.Default()
+	r.Use(compress.Middleware())
+	r.Use(mtls.Identify(identities))
+	if os.Getenv("OPENAPI_VALIDATE_REQUESTS") == "true" {
+		r.Use(openapi.Validator(openapi.ValidatorOptions{ValidateRequests: true}))
+	}
 	skill.SetRouter(r, h)
+	webhook.SetRouter(r, &webhook.Handler{Db: db, Admins: admins})
+	person.SetRouter(r, &person.Handler{Db: db, Admins: admins})
+	openapi.SetRouter(r)
+
+	gql, err := skillgraphql.NewHandler(storage)
+	if err != nil {
+		log.Panic(err)
+	}
+	skillgraphql.SetRouter(r, gql)
 
 	srv := http.Server{
 		Addr:              ":" + os.Getenv("PORT"),
@@ -43,6 +115,44 @@ func main() {
 		ReadHeaderTimeout: 3 * time.Second,
 	}
 
+	tlsEnabled := os.Getenv("TLS_CERT_FILE") != ""
+	if tlsEnabled {
+		reloader, err := mtls.NewReloader(os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE"), os.Getenv("TLS_CLIENT_CA_FILE"))
+		if err != nil {
+			log.Panic(err)
+		}
+		srv.TLSConfig = reloader.TLSConfig()
+		go reloader.Watch(ctx, 30*time.Second)
+	}
+
+	if port := os.Getenv("GRPC_PORT"); port != "" {
+		opts := []grpc.ServerOption{
+			grpc.UnaryInterceptor(skillgrpc.Identify(identities)),
+			grpc.ChainStreamInterceptor(skillgrpc.IdentifyStream(identities)),
+		}
+		if tlsEnabled {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(srv.TLSConfig)))
+		}
+		grpcSrv := skillgrpc.NewServer(storage, opts...)
+
+		lis, err := net.Listen("tcp", ":"+port)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		go func() {
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Panic(err)
+			}
+		}()
+
+		go func() {
+			<-ctx.Done()
+			slog.Info("Shutting down gRPC server")
+			grpcSrv.GracefulStop()
+		}()
+	}
+
 	go func() {
 		<-ctx.Done()
 
@@ -50,12 +160,18 @@ func main() {
 		defer cancel()
 
 		slog.Info("Shutting down server")
+		changes.Close()
 		if err := srv.Shutdown(ctx); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
-	if err := srv.ListenAndServe(); err != nil {
+	if tlsEnabled {
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil {
 		if !errors.Is(err, http.ErrServerClosed) {
 			log.Panic(err)
 		}
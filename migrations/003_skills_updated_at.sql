@@ -0,0 +1,22 @@
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- skills_meta holds a single row recording when the skills table last
+-- changed, including deletes, for the Last-Modified header of the list.
+CREATE TABLE IF NOT EXISTS skills_meta (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_modified TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO skills_meta DEFAULT VALUES ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION touch_skills_meta() RETURNS trigger AS $$
+BEGIN
+    UPDATE skills_meta SET last_modified = now();
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_touch_meta ON skills;
+CREATE TRIGGER skills_touch_meta
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON skills
+    FOR EACH STATEMENT EXECUTE FUNCTION touch_skills_meta();
@@ -0,0 +1,6 @@
+ALTER TABLE skills
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS created_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS updated_by TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS skills_updated_at_idx ON skills (updated_at);
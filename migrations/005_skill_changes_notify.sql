@@ -0,0 +1,25 @@
+-- Notifies skill_changes with {"op", "key", "actor"} for every changed row.
+-- The actor is only known for deletes made through the API, which set
+-- skills.actor for their transaction.
+CREATE OR REPLACE FUNCTION notify_skill_change() RETURNS trigger AS $$
+DECLARE
+    changed skills;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+    PERFORM pg_notify('skill_changes', json_build_object(
+        'op', TG_OP,
+        'key', changed.key,
+        'actor', COALESCE(current_setting('skills.actor', true), '')
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_notify_change ON skills;
+CREATE TRIGGER skills_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON skills
+    FOR EACH ROW EXECUTE FUNCTION notify_skill_change();
@@ -0,0 +1,80 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    -- An empty list subscribes to every event type.
+    events TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    -- pending, delivered or dead
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INTEGER,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    status_code INTEGER,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempts_delivery_idx ON webhook_delivery_attempts (delivery_id, id);
+
+-- Queues a delivery of every skill change to each matching subscription, in
+-- the transaction of the change, so that no change is missed whichever
+-- client made it.
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+DECLARE
+    change_type TEXT;
+    data JSONB;
+    event JSONB;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        change_type := 'skill.created';
+        data := to_jsonb(NEW);
+    ELSIF TG_OP = 'UPDATE' THEN
+        change_type := 'skill.updated';
+        data := to_jsonb(NEW);
+    ELSE
+        change_type := 'skill.deleted';
+        data := jsonb_build_object('key', OLD.key, 'deleted_by', COALESCE(current_setting('skills.actor', true), ''));
+    END IF;
+
+    event := jsonb_build_object(
+        'id', gen_random_uuid(),
+        'type', change_type,
+        'occurred_at', now(),
+        'data', data
+    );
+
+    INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+    SELECT id, (event->>'id')::uuid, change_type, event
+    FROM webhook_subscriptions
+    WHERE cardinality(events) = 0 OR change_type = ANY(events);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_enqueue_webhooks ON skills;
+CREATE TRIGGER skills_enqueue_webhooks
+    AFTER INSERT OR UPDATE OR DELETE ON skills
+    FOR EACH ROW EXECUTE FUNCTION enqueue_webhook_deliveries();
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_key TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_aggregate_key_idx ON outbox (aggregate_key, id);
+
+-- Records every skill change in the transaction that makes it, so that an
+-- event is published if and only if the change is committed.
+CREATE OR REPLACE FUNCTION write_skill_outbox() RETURNS trigger AS $$
+DECLARE
+    deleted_by TEXT := COALESCE(current_setting('skills.actor', true), '');
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (NEW.key, 'skill.created', to_jsonb(NEW), NEW.updated_by);
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (NEW.key, 'skill.updated', to_jsonb(NEW), NEW.updated_by);
+    ELSE
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (OLD.key, 'skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', deleted_by), deleted_by);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_write_outbox ON skills;
+CREATE TRIGGER skills_write_outbox
+    AFTER INSERT OR UPDATE OR DELETE ON skills
+    FOR EACH ROW EXECUTE FUNCTION write_skill_outbox();
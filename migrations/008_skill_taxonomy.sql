@@ -0,0 +1,8 @@
+-- Skills form a tree: a skill with a NULL parent_key is a root. A skill with
+-- children cannot be deleted.
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS parent_key TEXT REFERENCES skills (key) ON UPDATE CASCADE;
+
+ALTER TABLE skills DROP CONSTRAINT IF EXISTS skills_parent_not_self;
+ALTER TABLE skills ADD CONSTRAINT skills_parent_not_self CHECK (parent_key <> key);
+
+CREATE INDEX IF NOT EXISTS skills_parent_key_idx ON skills (parent_key);
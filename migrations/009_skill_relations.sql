@@ -0,0 +1,17 @@
+-- Typed edges between skills. "requires" edges are directed and acyclic;
+-- "related" edges are symmetric and stored once, with the smaller key first.
+-- Keys are compared byte by byte, whatever the database collation, so that
+-- the application orders them the same way. Edges go with their skills when
+-- those are deleted or renamed.
+CREATE TABLE IF NOT EXISTS skill_relations (
+    from_key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+    to_key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('requires', 'related')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (from_key, type, to_key),
+    CHECK (from_key <> to_key),
+    CHECK (type <> 'related' OR from_key < to_key COLLATE "C")
+);
+
+CREATE INDEX IF NOT EXISTS skill_relations_to_key_idx ON skill_relations (to_key, type);
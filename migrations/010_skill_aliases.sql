@@ -0,0 +1,44 @@
+-- Other names a skill is known by, such as "golang" for go. Aliases match
+-- case-insensitively, and a name is either a skill key or an alias, never
+-- both. The triggers take an advisory lock on the name so that a skill and an
+-- alias created concurrently cannot claim the same one.
+CREATE TABLE IF NOT EXISTS skill_aliases (
+    alias TEXT PRIMARY KEY,
+    key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS skill_aliases_lower_alias_idx ON skill_aliases (lower(alias));
+CREATE INDEX IF NOT EXISTS skill_aliases_key_idx ON skill_aliases (key);
+CREATE INDEX IF NOT EXISTS skills_lower_key_idx ON skills (lower(key));
+
+CREATE OR REPLACE FUNCTION check_skill_alias() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('skill_name:' || lower(NEW.alias)));
+    IF EXISTS (SELECT 1 FROM skills WHERE lower(key) = lower(NEW.alias)) THEN
+        RAISE EXCEPTION 'alias "%" is a skill key', NEW.alias USING ERRCODE = 'unique_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skill_aliases_check ON skill_aliases;
+CREATE TRIGGER skill_aliases_check
+    BEFORE INSERT OR UPDATE OF alias ON skill_aliases
+    FOR EACH ROW EXECUTE FUNCTION check_skill_alias();
+
+CREATE OR REPLACE FUNCTION check_skill_key() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('skill_name:' || lower(NEW.key)));
+    IF EXISTS (SELECT 1 FROM skill_aliases WHERE lower(alias) = lower(NEW.key)) THEN
+        RAISE EXCEPTION 'key "%" is a skill alias', NEW.key USING ERRCODE = 'unique_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_check_key ON skills;
+CREATE TRIGGER skills_check_key
+    BEFORE INSERT OR UPDATE OF key ON skills
+    FOR EACH ROW EXECUTE FUNCTION check_skill_key();
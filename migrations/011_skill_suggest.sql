@@ -0,0 +1,5 @@
+-- Prefix indexes for typeahead suggestions. text_pattern_ops lets
+-- lower(...) LIKE 'prefix%' use the index whatever the database collation.
+CREATE INDEX IF NOT EXISTS skills_lower_key_pattern_idx ON skills (lower(key) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS skills_lower_name_pattern_idx ON skills (lower(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS skill_aliases_lower_alias_pattern_idx ON skill_aliases (lower(alias) text_pattern_ops);
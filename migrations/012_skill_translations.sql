@@ -0,0 +1,11 @@
+-- Names and descriptions of a skill in other locales than the default one,
+-- which stays in skills. Locales are canonical BCP 47 tags, such as "th".
+CREATE TABLE IF NOT EXISTS skill_translations (
+    key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+    locale TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (key, locale)
+);
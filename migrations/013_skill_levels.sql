@@ -0,0 +1,9 @@
+-- The proficiency ladder of a skill, numbered from 1. A skill without levels
+-- uses the ladder of its nearest ancestor in the taxonomy that has one.
+CREATE TABLE IF NOT EXISTS skill_levels (
+    key TEXT NOT NULL REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE,
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 10),
+    name TEXT NOT NULL CHECK (name <> ''),
+    description TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (key, level)
+);
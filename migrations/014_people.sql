@@ -0,0 +1,27 @@
+-- People and the skills they claim, each at a level of the skill's ladder.
+-- A person's profile belongs to the client identity that created it. Claims
+-- follow a skill that is renamed and go away with a skill that is deleted.
+CREATE TABLE IF NOT EXISTS people (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    identity TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS person_skills (
+    person_id BIGINT NOT NULL,
+    skill_key TEXT NOT NULL,
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 10),
+    last_used DATE,
+    interested BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (person_id, skill_key),
+    CONSTRAINT person_skills_person_fkey FOREIGN KEY (person_id) REFERENCES people (id) ON DELETE CASCADE,
+    CONSTRAINT person_skills_skill_fkey FOREIGN KEY (skill_key) REFERENCES skills (key) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS person_skills_skill_key_idx ON person_skills (skill_key, level);
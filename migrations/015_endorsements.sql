@@ -0,0 +1,25 @@
+-- Colleagues vouching for a skill someone claims. Endorsements go away with
+-- the claim, and with the endorser. endorsement_log records when each
+-- endorsement was given, and is what the daily limit counts, so that
+-- withdrawing an endorsement does not give it back.
+CREATE TABLE IF NOT EXISTS endorsements (
+    person_id BIGINT NOT NULL,
+    skill_key TEXT NOT NULL,
+    endorser_id BIGINT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (person_id, skill_key, endorser_id),
+    CONSTRAINT endorsements_claim_fkey FOREIGN KEY (person_id, skill_key)
+        REFERENCES person_skills (person_id, skill_key) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT endorsements_endorser_fkey FOREIGN KEY (endorser_id) REFERENCES people (id) ON DELETE CASCADE,
+    CONSTRAINT endorsements_not_self CHECK (endorser_id <> person_id)
+);
+CREATE INDEX IF NOT EXISTS endorsements_endorser_idx ON endorsements (endorser_id, created_at);
+CREATE INDEX IF NOT EXISTS endorsements_skill_key_idx ON endorsements (skill_key);
+
+CREATE TABLE IF NOT EXISTS endorsement_log (
+    endorser_id BIGINT NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS endorsement_log_endorser_idx ON endorsement_log (endorser_id, created_at);
@@ -0,0 +1,81 @@
+-- A rename changes the key of a skill, which consumers use to identify it. It
+-- is published as the deletion of the old key followed by the creation of the
+-- new one, so that nothing keeps the old key around. The rename's actor is
+-- the one recorded in updated_by.
+CREATE OR REPLACE FUNCTION notify_skill_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+        PERFORM pg_notify('skill_changes', json_build_object('op', 'DELETE', 'key', OLD.key, 'actor', NEW.updated_by)::text);
+        PERFORM pg_notify('skill_changes', json_build_object('op', 'INSERT', 'key', NEW.key, 'actor', NEW.updated_by)::text);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('skill_changes', json_build_object(
+            'op', TG_OP,
+            'key', OLD.key,
+            'actor', COALESCE(current_setting('skills.actor', true), '')
+        )::text);
+    ELSE
+        PERFORM pg_notify('skill_changes', json_build_object(
+            'op', TG_OP,
+            'key', NEW.key,
+            'actor', COALESCE(current_setting('skills.actor', true), '')
+        )::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Queues one event for each matching webhook subscription.
+CREATE OR REPLACE FUNCTION enqueue_webhook_event(change_type TEXT, data JSONB) RETURNS void AS $$
+DECLARE
+    event JSONB := jsonb_build_object(
+        'id', gen_random_uuid(),
+        'type', change_type,
+        'occurred_at', now(),
+        'data', data
+    );
+BEGIN
+    INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+    SELECT id, (event->>'id')::uuid, change_type, event
+    FROM webhook_subscriptions
+    WHERE cardinality(events) = 0 OR change_type = ANY(events);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM enqueue_webhook_event('skill.created', to_jsonb(NEW));
+    ELSIF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+        PERFORM enqueue_webhook_event('skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', NEW.updated_by));
+        PERFORM enqueue_webhook_event('skill.created', to_jsonb(NEW));
+    ELSIF TG_OP = 'UPDATE' THEN
+        PERFORM enqueue_webhook_event('skill.updated', to_jsonb(NEW));
+    ELSE
+        PERFORM enqueue_webhook_event('skill.deleted',
+            jsonb_build_object('key', OLD.key, 'deleted_by', COALESCE(current_setting('skills.actor', true), '')));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION write_skill_outbox() RETURNS trigger AS $$
+DECLARE
+    deleted_by TEXT := COALESCE(current_setting('skills.actor', true), '');
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (NEW.key, 'skill.created', to_jsonb(NEW), NEW.updated_by);
+    ELSIF TG_OP = 'UPDATE' AND OLD.key <> NEW.key THEN
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (OLD.key, 'skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', NEW.updated_by), NEW.updated_by),
+            (NEW.key, 'skill.created', to_jsonb(NEW), NEW.updated_by);
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (NEW.key, 'skill.updated', to_jsonb(NEW), NEW.updated_by);
+    ELSE
+        INSERT INTO outbox (aggregate_key, event_type, payload, actor)
+        VALUES (OLD.key, 'skill.deleted', jsonb_build_object('key', OLD.key, 'deleted_by', deleted_by), deleted_by);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
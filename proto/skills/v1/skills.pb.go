@@ -0,0 +1,506 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: skills/v1/skills.proto
+
+package skillsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Skill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Logo          string                 `protobuf:"bytes,4,opt,name=logo,proto3" json:"logo,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	mi := &file_skills_v1_skills_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Skill) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Skill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Skill) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Skill) GetLogo() string {
+	if x != nil {
+		return x.Logo
+	}
+	return ""
+}
+
+func (x *Skill) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSkillRequest) Reset() {
+	*x = GetSkillRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSkillRequest) ProtoMessage() {}
+
+func (x *GetSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSkillRequest.ProtoReflect.Descriptor instead.
+func (*GetSkillRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSkillRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListSkillsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSkillsRequest) Reset() {
+	*x = ListSkillsRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSkillsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSkillsRequest) ProtoMessage() {}
+
+func (x *ListSkillsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSkillsRequest.ProtoReflect.Descriptor instead.
+func (*ListSkillsRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{2}
+}
+
+type CreateSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         *Skill                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSkillRequest) Reset() {
+	*x = CreateSkillRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSkillRequest) ProtoMessage() {}
+
+func (x *CreateSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSkillRequest.ProtoReflect.Descriptor instead.
+func (*CreateSkillRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSkillRequest) GetSkill() *Skill {
+	if x != nil {
+		return x.Skill
+	}
+	return nil
+}
+
+type UpdateSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         *Skill                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSkillRequest) Reset() {
+	*x = UpdateSkillRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSkillRequest) ProtoMessage() {}
+
+func (x *UpdateSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSkillRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSkillRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateSkillRequest) GetSkill() *Skill {
+	if x != nil {
+		return x.Skill
+	}
+	return nil
+}
+
+type PatchSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         *Skill                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchSkillRequest) Reset() {
+	*x = PatchSkillRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchSkillRequest) ProtoMessage() {}
+
+func (x *PatchSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchSkillRequest.ProtoReflect.Descriptor instead.
+func (*PatchSkillRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PatchSkillRequest) GetSkill() *Skill {
+	if x != nil {
+		return x.Skill
+	}
+	return nil
+}
+
+func (x *PatchSkillRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSkillRequest) Reset() {
+	*x = DeleteSkillRequest{}
+	mi := &file_skills_v1_skills_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSkillRequest) ProtoMessage() {}
+
+func (x *DeleteSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSkillRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSkillRequest) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteSkillRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteSkillResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSkillResponse) Reset() {
+	*x = DeleteSkillResponse{}
+	mi := &file_skills_v1_skills_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSkillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSkillResponse) ProtoMessage() {}
+
+func (x *DeleteSkillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skills_v1_skills_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSkillResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSkillResponse) Descriptor() ([]byte, []int) {
+	return file_skills_v1_skills_proto_rawDescGZIP(), []int{7}
+}
+
+var File_skills_v1_skills_proto protoreflect.FileDescriptor
+
+const file_skills_v1_skills_proto_rawDesc = "" +
+	"\n" +
+	"\x16skills/v1/skills.proto\x12\tskills.v1\x1a google/protobuf/field_mask.proto\"w\n" +
+	"\x05Skill\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04logo\x18\x04 \x01(\tR\x04logo\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\"#\n" +
+	"\x0fGetSkillRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\x13\n" +
+	"\x11ListSkillsRequest\"<\n" +
+	"\x12CreateSkillRequest\x12&\n" +
+	"\x05skill\x18\x01 \x01(\v2\x10.skills.v1.SkillR\x05skill\"<\n" +
+	"\x12UpdateSkillRequest\x12&\n" +
+	"\x05skill\x18\x01 \x01(\v2\x10.skills.v1.SkillR\x05skill\"x\n" +
+	"\x11PatchSkillRequest\x12&\n" +
+	"\x05skill\x18\x01 \x01(\v2\x10.skills.v1.SkillR\x05skill\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"&\n" +
+	"\x12DeleteSkillRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\x15\n" +
+	"\x13DeleteSkillResponse2\x94\x03\n" +
+	"\fSkillService\x128\n" +
+	"\bGetSkill\x12\x1a.skills.v1.GetSkillRequest\x1a\x10.skills.v1.Skill\x12>\n" +
+	"\n" +
+	"ListSkills\x12\x1c.skills.v1.ListSkillsRequest\x1a\x10.skills.v1.Skill0\x01\x12>\n" +
+	"\vCreateSkill\x12\x1d.skills.v1.CreateSkillRequest\x1a\x10.skills.v1.Skill\x12>\n" +
+	"\vUpdateSkill\x12\x1d.skills.v1.UpdateSkillRequest\x1a\x10.skills.v1.Skill\x12<\n" +
+	"\n" +
+	"PatchSkill\x12\x1c.skills.v1.PatchSkillRequest\x1a\x10.skills.v1.Skill\x12L\n" +
+	"\vDeleteSkill\x12\x1d.skills.v1.DeleteSkillRequest\x1a\x1e.skills.v1.DeleteSkillResponseB$Z\"skillsapi/proto/skills/v1;skillsv1b\x06proto3"
+
+var (
+	file_skills_v1_skills_proto_rawDescOnce sync.Once
+	file_skills_v1_skills_proto_rawDescData []byte
+)
+
+func file_skills_v1_skills_proto_rawDescGZIP() []byte {
+	file_skills_v1_skills_proto_rawDescOnce.Do(func() {
+		file_skills_v1_skills_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skills_v1_skills_proto_rawDesc), len(file_skills_v1_skills_proto_rawDesc)))
+	})
+	return file_skills_v1_skills_proto_rawDescData
+}
+
+var file_skills_v1_skills_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_skills_v1_skills_proto_goTypes = []any{
+	(*Skill)(nil),                 // 0: skills.v1.Skill
+	(*GetSkillRequest)(nil),       // 1: skills.v1.GetSkillRequest
+	(*ListSkillsRequest)(nil),     // 2: skills.v1.ListSkillsRequest
+	(*CreateSkillRequest)(nil),    // 3: skills.v1.CreateSkillRequest
+	(*UpdateSkillRequest)(nil),    // 4: skills.v1.UpdateSkillRequest
+	(*PatchSkillRequest)(nil),     // 5: skills.v1.PatchSkillRequest
+	(*DeleteSkillRequest)(nil),    // 6: skills.v1.DeleteSkillRequest
+	(*DeleteSkillResponse)(nil),   // 7: skills.v1.DeleteSkillResponse
+	(*fieldmaskpb.FieldMask)(nil), // 8: google.protobuf.FieldMask
+}
+var file_skills_v1_skills_proto_depIdxs = []int32{
+	0,  // 0: skills.v1.CreateSkillRequest.skill:type_name -> skills.v1.Skill
+	0,  // 1: skills.v1.UpdateSkillRequest.skill:type_name -> skills.v1.Skill
+	0,  // 2: skills.v1.PatchSkillRequest.skill:type_name -> skills.v1.Skill
+	8,  // 3: skills.v1.PatchSkillRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 4: skills.v1.SkillService.GetSkill:input_type -> skills.v1.GetSkillRequest
+	2,  // 5: skills.v1.SkillService.ListSkills:input_type -> skills.v1.ListSkillsRequest
+	3,  // 6: skills.v1.SkillService.CreateSkill:input_type -> skills.v1.CreateSkillRequest
+	4,  // 7: skills.v1.SkillService.UpdateSkill:input_type -> skills.v1.UpdateSkillRequest
+	5,  // 8: skills.v1.SkillService.PatchSkill:input_type -> skills.v1.PatchSkillRequest
+	6,  // 9: skills.v1.SkillService.DeleteSkill:input_type -> skills.v1.DeleteSkillRequest
+	0,  // 10: skills.v1.SkillService.GetSkill:output_type -> skills.v1.Skill
+	0,  // 11: skills.v1.SkillService.ListSkills:output_type -> skills.v1.Skill
+	0,  // 12: skills.v1.SkillService.CreateSkill:output_type -> skills.v1.Skill
+	0,  // 13: skills.v1.SkillService.UpdateSkill:output_type -> skills.v1.Skill
+	0,  // 14: skills.v1.SkillService.PatchSkill:output_type -> skills.v1.Skill
+	7,  // 15: skills.v1.SkillService.DeleteSkill:output_type -> skills.v1.DeleteSkillResponse
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_skills_v1_skills_proto_init() }
+func file_skills_v1_skills_proto_init() {
+	if File_skills_v1_skills_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skills_v1_skills_proto_rawDesc), len(file_skills_v1_skills_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skills_v1_skills_proto_goTypes,
+		DependencyIndexes: file_skills_v1_skills_proto_depIdxs,
+		MessageInfos:      file_skills_v1_skills_proto_msgTypes,
+	}.Build()
+	File_skills_v1_skills_proto = out.File
+	file_skills_v1_skills_proto_goTypes = nil
+	file_skills_v1_skills_proto_depIdxs = nil
+}
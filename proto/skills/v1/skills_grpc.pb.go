@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: skills/v1/skills.proto
+
+package skillsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SkillService_GetSkill_FullMethodName    = "/skills.v1.SkillService/GetSkill"
+	SkillService_ListSkills_FullMethodName  = "/skills.v1.SkillService/ListSkills"
+	SkillService_CreateSkill_FullMethodName = "/skills.v1.SkillService/CreateSkill"
+	SkillService_UpdateSkill_FullMethodName = "/skills.v1.SkillService/UpdateSkill"
+	SkillService_PatchSkill_FullMethodName  = "/skills.v1.SkillService/PatchSkill"
+	SkillService_DeleteSkill_FullMethodName = "/skills.v1.SkillService/DeleteSkill"
+)
+
+// SkillServiceClient is the client API for SkillService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SkillServiceClient interface {
+	GetSkill(ctx context.Context, in *GetSkillRequest, opts ...grpc.CallOption) (*Skill, error)
+	ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Skill], error)
+	CreateSkill(ctx context.Context, in *CreateSkillRequest, opts ...grpc.CallOption) (*Skill, error)
+	UpdateSkill(ctx context.Context, in *UpdateSkillRequest, opts ...grpc.CallOption) (*Skill, error)
+	// PatchSkill updates only the fields named in update_mask: name,
+	// description, logo and tags.
+	PatchSkill(ctx context.Context, in *PatchSkillRequest, opts ...grpc.CallOption) (*Skill, error)
+	DeleteSkill(ctx context.Context, in *DeleteSkillRequest, opts ...grpc.CallOption) (*DeleteSkillResponse, error)
+}
+
+type skillServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSkillServiceClient(cc grpc.ClientConnInterface) SkillServiceClient {
+	return &skillServiceClient{cc}
+}
+
+func (c *skillServiceClient) GetSkill(ctx context.Context, in *GetSkillRequest, opts ...grpc.CallOption) (*Skill, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Skill)
+	err := c.cc.Invoke(ctx, SkillService_GetSkill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skillServiceClient) ListSkills(ctx context.Context, in *ListSkillsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Skill], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SkillService_ServiceDesc.Streams[0], SkillService_ListSkills_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSkillsRequest, Skill]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SkillService_ListSkillsClient = grpc.ServerStreamingClient[Skill]
+
+func (c *skillServiceClient) CreateSkill(ctx context.Context, in *CreateSkillRequest, opts ...grpc.CallOption) (*Skill, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Skill)
+	err := c.cc.Invoke(ctx, SkillService_CreateSkill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skillServiceClient) UpdateSkill(ctx context.Context, in *UpdateSkillRequest, opts ...grpc.CallOption) (*Skill, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Skill)
+	err := c.cc.Invoke(ctx, SkillService_UpdateSkill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skillServiceClient) PatchSkill(ctx context.Context, in *PatchSkillRequest, opts ...grpc.CallOption) (*Skill, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Skill)
+	err := c.cc.Invoke(ctx, SkillService_PatchSkill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *skillServiceClient) DeleteSkill(ctx context.Context, in *DeleteSkillRequest, opts ...grpc.CallOption) (*DeleteSkillResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSkillResponse)
+	err := c.cc.Invoke(ctx, SkillService_DeleteSkill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SkillServiceServer is the server API for SkillService service.
+// All implementations must embed UnimplementedSkillServiceServer
+// for forward compatibility.
+type SkillServiceServer interface {
+	GetSkill(context.Context, *GetSkillRequest) (*Skill, error)
+	ListSkills(*ListSkillsRequest, grpc.ServerStreamingServer[Skill]) error
+	CreateSkill(context.Context, *CreateSkillRequest) (*Skill, error)
+	UpdateSkill(context.Context, *UpdateSkillRequest) (*Skill, error)
+	// PatchSkill updates only the fields named in update_mask: name,
+	// description, logo and tags.
+	PatchSkill(context.Context, *PatchSkillRequest) (*Skill, error)
+	DeleteSkill(context.Context, *DeleteSkillRequest) (*DeleteSkillResponse, error)
+	mustEmbedUnimplementedSkillServiceServer()
+}
+
+// UnimplementedSkillServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSkillServiceServer struct{}
+
+func (UnimplementedSkillServiceServer) GetSkill(context.Context, *GetSkillRequest) (*Skill, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSkill not implemented")
+}
+func (UnimplementedSkillServiceServer) ListSkills(*ListSkillsRequest, grpc.ServerStreamingServer[Skill]) error {
+	return status.Errorf(codes.Unimplemented, "method ListSkills not implemented")
+}
+func (UnimplementedSkillServiceServer) CreateSkill(context.Context, *CreateSkillRequest) (*Skill, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSkill not implemented")
+}
+func (UnimplementedSkillServiceServer) UpdateSkill(context.Context, *UpdateSkillRequest) (*Skill, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSkill not implemented")
+}
+func (UnimplementedSkillServiceServer) PatchSkill(context.Context, *PatchSkillRequest) (*Skill, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchSkill not implemented")
+}
+func (UnimplementedSkillServiceServer) DeleteSkill(context.Context, *DeleteSkillRequest) (*DeleteSkillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSkill not implemented")
+}
+func (UnimplementedSkillServiceServer) mustEmbedUnimplementedSkillServiceServer() {}
+func (UnimplementedSkillServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeSkillServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SkillServiceServer will
+// result in compilation errors.
+type UnsafeSkillServiceServer interface {
+	mustEmbedUnimplementedSkillServiceServer()
+}
+
+func RegisterSkillServiceServer(s grpc.ServiceRegistrar, srv SkillServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSkillServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SkillService_ServiceDesc, srv)
+}
+
+func _SkillService_GetSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkillServiceServer).GetSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SkillService_GetSkill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkillServiceServer).GetSkill(ctx, req.(*GetSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkillService_ListSkills_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSkillsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SkillServiceServer).ListSkills(m, &grpc.GenericServerStream[ListSkillsRequest, Skill]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SkillService_ListSkillsServer = grpc.ServerStreamingServer[Skill]
+
+func _SkillService_CreateSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkillServiceServer).CreateSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SkillService_CreateSkill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkillServiceServer).CreateSkill(ctx, req.(*CreateSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkillService_UpdateSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkillServiceServer).UpdateSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SkillService_UpdateSkill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkillServiceServer).UpdateSkill(ctx, req.(*UpdateSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkillService_PatchSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkillServiceServer).PatchSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SkillService_PatchSkill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkillServiceServer).PatchSkill(ctx, req.(*PatchSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SkillService_DeleteSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSkillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SkillServiceServer).DeleteSkill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SkillService_DeleteSkill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SkillServiceServer).DeleteSkill(ctx, req.(*DeleteSkillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SkillService_ServiceDesc is the grpc.ServiceDesc for SkillService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SkillService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "skills.v1.SkillService",
+	HandlerType: (*SkillServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSkill",
+			Handler:    _SkillService_GetSkill_Handler,
+		},
+		{
+			MethodName: "CreateSkill",
+			Handler:    _SkillService_CreateSkill_Handler,
+		},
+		{
+			MethodName: "UpdateSkill",
+			Handler:    _SkillService_UpdateSkill_Handler,
+		},
+		{
+			MethodName: "PatchSkill",
+			Handler:    _SkillService_PatchSkill_Handler,
+		},
+		{
+			MethodName: "DeleteSkill",
+			Handler:    _SkillService_DeleteSkill_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSkills",
+			Handler:       _SkillService_ListSkills_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "skills/v1/skills.proto",
+}